@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parseFlags splits command arguments into positional arguments and --flags.
+// Flags take the following argument as their value unless they are listed in
+// boolFlags, in which case their value is "true".
+func parseFlags(args []string, boolFlags ...string) ([]string, map[string]string, error) {
+
+	positional := []string{}
+	flags := map[string]string{}
+	for i := 0; i < len(args); i++ {
+		if !strings.HasPrefix(args[i], "--") {
+			positional = append(positional, args[i])
+			continue
+		}
+		name := strings.TrimPrefix(args[i], "--")
+		if name == "" {
+			return nil, nil, fmt.Errorf("missing flag name after --")
+		}
+		isBool := false
+		for _, boolFlag := range boolFlags {
+			if name == boolFlag {
+				isBool = true
+			}
+		}
+		if isBool {
+			flags[name] = "true"
+			continue
+		}
+		if i+1 >= len(args) {
+			return nil, nil, fmt.Errorf("missing value for --%s", name)
+		}
+		flags[name] = args[i+1]
+		i++
+	}
+	return positional, flags, nil
+}
+
+// checkFlags returns an error naming the first flag that is not in allowed.
+func checkFlags(flags map[string]string, allowed ...string) error {
+
+	for name := range flags {
+		known := false
+		for _, allowedName := range allowed {
+			if name == allowedName {
+				known = true
+			}
+		}
+		if !known {
+			return fmt.Errorf("unknown flag --%s", name)
+		}
+	}
+	return nil
+}
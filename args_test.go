@@ -0,0 +1,24 @@
+package main
+
+import "testing"
+
+func TestParseFlags(t *testing.T) {
+	positional, flags, err := parseFlags([]string{"pikachu", "--method", "machine", "--caught", "extra"}, "caught")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(positional) != 2 || positional[0] != "pikachu" || positional[1] != "extra" {
+		t.Errorf("The positional arguments do not match: %v", positional)
+	}
+	if flags["method"] != "machine" {
+		t.Errorf("The method flag does not match: %v", flags["method"])
+	}
+	if flags["caught"] != "true" {
+		t.Errorf("The caught flag does not match: %v", flags["caught"])
+	}
+
+	_, _, err = parseFlags([]string{"pikachu", "--method"})
+	if err == nil {
+		t.Errorf("expected an error for a flag without a value")
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// fallbackLanguage is used for text that is missing in the chosen language.
+// Effect descriptions in particular are only fully translated into English.
+const fallbackLanguage = "en"
+
+func commandAbility(cfg *config, args ...string) error {
+
+	if len(args) != 1 {
+		return fmt.Errorf("Please enter one ability name or id after the ability command")
+	}
+
+	ability, err := cfg.pokeapiClient.GetAbility(args[0])
+	if err != nil {
+		return err
+	}
+
+	name := ability.Name
+	for _, entry := range ability.Names {
+		if strings.EqualFold(entry.Language.Name, cfg.language) {
+			name = entry.Name
+		}
+	}
+	fmt.Printf("\nName: %s", name)
+
+	shortEffect, effect := "", ""
+	for _, language := range []string{fallbackLanguage, cfg.language} {
+		for _, entry := range ability.EffectEntries {
+			if strings.EqualFold(entry.Language.Name, language) {
+				shortEffect, effect = entry.ShortEffect, entry.Effect
+			}
+		}
+	}
+	if effect == "" {
+		fmt.Printf("\nNo effect description available.")
+	} else {
+		fmt.Printf("\nShort effect: %s", strings.Join(strings.Fields(shortEffect), " "))
+		fmt.Printf("\nEffect: %s", strings.Join(strings.Fields(effect), " "))
+	}
+
+	fmt.Printf("\n\nPokemon with this ability:")
+	for _, content := range ability.Pokemon {
+		if content.IsHidden {
+			fmt.Printf("\n * %s (hidden)", content.Pokemon.Name)
+		} else {
+			fmt.Printf("\n * %s", content.Pokemon.Name)
+		}
+	}
+	fmt.Printf("\n\n")
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+func commandCache(cfg *config, args ...string) error {
+
+	if len(args) == 0 {
+		return fmt.Errorf("Use cache stats, cache list, cache clear or cache purge <url>")
+	}
+
+	// the arguments keep their case for purge, since URLs are case sensitive
+	switch strings.ToLower(args[0]) {
+	case "stats":
+		stats := cfg.pokeapiClient.CacheStats()
+		lookups := stats.Hits + stats.StaleHits + stats.Misses
+		fmt.Printf("\nEntries: %v", stats.Entries)
+		if stats.MaxBytes > 0 {
+			fmt.Printf("\nSize: %s of %s", formatBytes(stats.Bytes), formatBytes(stats.MaxBytes))
+		} else {
+			fmt.Printf("\nSize: %s", formatBytes(stats.Bytes))
+		}
+		fmt.Printf("\nHits: %v (%s of lookups)", stats.Hits, percent(stats.Hits, lookups))
+		fmt.Printf("\nStale hits: %v (%s of lookups)", stats.StaleHits, percent(stats.StaleHits, lookups))
+		fmt.Printf("\nMisses: %v", stats.Misses)
+		fmt.Printf("\nRevalidated without downloading: %v", stats.Revalidations)
+		fmt.Printf("\nEvictions: %v", stats.Evictions)
+		fmt.Printf("\nExpired: %v", stats.Expired)
+		if disk, exists := cfg.pokeapiClient.DiskCacheStats(); exists {
+			fmt.Printf("\n\nOn disk in %s:", disk.Dir)
+			fmt.Printf("\nEntries: %v", disk.Entries)
+			fmt.Printf("\nSize: %s of %s", formatBytes(disk.Bytes), formatBytes(disk.MaxBytes))
+			fmt.Printf("\nHits: %v", disk.Hits)
+			fmt.Printf("\nMisses: %v", disk.Misses)
+		}
+		fmt.Printf("\n\n")
+
+	case "list":
+		entries := cfg.pokeapiClient.CacheEntries()
+		if len(entries) == 0 {
+			fmt.Printf("\nThe cache is empty.\n\n")
+			return nil
+		}
+		fmt.Printf("\nCached responses, most recently used first:")
+		for _, entry := range entries {
+			age := time.Since(entry.CreatedAt).Round(time.Second)
+			size := formatBytes(entry.Size)
+			if entry.Compressed {
+				size = fmt.Sprintf("%s, %s uncompressed", formatBytes(entry.Size), formatBytes(entry.RawSize))
+			}
+			if entry.Stale {
+				fmt.Printf("\n * %s (%s, %s old, stale)", entry.Key, size, age)
+			} else {
+				expiresIn := time.Until(entry.ExpiresAt).Round(time.Second)
+				fmt.Printf("\n * %s (%s, %s old, expires in %s)", entry.Key, size, age, expiresIn)
+			}
+		}
+		fmt.Printf("\n\n")
+
+	case "clear":
+		if err := cfg.pokeapiClient.ClearCache(); err != nil {
+			return fmt.Errorf("Could not clear the disk cache: %w", err)
+		}
+		fmt.Println("The cache has been cleared.")
+
+	case "purge":
+		if len(args) != 2 {
+			return fmt.Errorf("Please enter one URL after cache purge")
+		}
+		if !cfg.pokeapiClient.PurgeCache(args[1]) {
+			return fmt.Errorf("%s is not in the cache", args[1])
+		}
+		fmt.Printf("%s has been removed from the cache.\n", args[1])
+
+	default:
+		return fmt.Errorf("Unknown cache option %s. Use stats, list, clear or purge <url>", args[0])
+	}
+
+	return nil
+}
+
+func formatBytes(size int) string {
+
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(size)/(1<<10))
+	}
+	return fmt.Sprintf("%v B", size)
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aspiringVegetarian/PokedexCLI/internal/pokeapi"
+)
+
+func commandCompare(cfg *config, args ...string) error {
+
+	if len(args) < 2 {
+		return fmt.Errorf("Please enter at least two Pokemon names or ids after the compare command")
+	}
+
+	// fetch every Pokemon at once rather than one after the other
+	pokemon := make([]pokeapi.SpecificPokemonResp, len(args))
+	errs := make([]error, len(args))
+	var wg sync.WaitGroup
+	for i := range args {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pokemon[i], errs[i] = cfg.pokeapiClient.ExplorePokemon(&args[i])
+		}()
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	header := make([]string, len(pokemon))
+	for i, info := range pokemon {
+		header[i] = info.Name
+	}
+
+	rows := []tableRow{}
+	totals := make([]float64, len(pokemon))
+	for _, stat := range statNames {
+		row := tableRow{label: stat, cells: make([]string, len(pokemon))}
+		values := make([]float64, len(pokemon))
+		for i, info := range pokemon {
+			for _, content := range info.Stats {
+				if content.Stat.Name == stat {
+					values[i] = float64(content.BaseStat)
+				}
+			}
+			totals[i] += values[i]
+			row.cells[i] = fmt.Sprint(values[i])
+		}
+		row.highlight = highlightMax(values, nil)
+		rows = append(rows, row)
+	}
+
+	totalRow := tableRow{label: "total", cells: make([]string, len(pokemon)), highlight: highlightMax(totals, nil)}
+	heightRow := tableRow{label: "height", cells: make([]string, len(pokemon))}
+	weightRow := tableRow{label: "weight", cells: make([]string, len(pokemon))}
+	typesRow := tableRow{label: "types", cells: make([]string, len(pokemon))}
+	abilitiesRow := tableRow{label: "abilities", cells: make([]string, len(pokemon))}
+	for i, info := range pokemon {
+		totalRow.cells[i] = fmt.Sprint(totals[i])
+		heightRow.cells[i] = fmt.Sprintf("%.1f m", float64(info.Height)/10)
+		weightRow.cells[i] = fmt.Sprintf("%.1f kg", float64(info.Weight)/10)
+		typesRow.cells[i] = strings.Join(pokemonTypeNames(info), "/")
+		abilities := []string{}
+		for _, content := range info.Abilities {
+			abilities = append(abilities, content.Ability.Name)
+		}
+		abilitiesRow.cells[i] = strings.Join(abilities, ", ")
+	}
+	rows = append(rows, totalRow, heightRow, weightRow, typesRow, abilitiesRow)
+
+	// each matchup row shows how hard every other Pokemon can hit the defender
+	for j, defender := range pokemon {
+		row := tableRow{label: "vs " + defender.Name, cells: make([]string, len(pokemon))}
+		values := make([]float64, len(pokemon))
+		skip := make([]bool, len(pokemon))
+		for i, attacker := range pokemon {
+			if i == j {
+				row.cells[i] = "-"
+				skip[i] = true
+				continue
+			}
+			multiplier, err := bestDamageMultiplier(cfg, pokemonTypeNames(attacker), pokemonTypeNames(defender))
+			if err != nil {
+				return err
+			}
+			values[i] = multiplier
+			row.cells[i] = formatMultiplier(multiplier)
+		}
+		row.highlight = highlightMax(values, skip)
+		rows = append(rows, row)
+	}
+
+	fmt.Println()
+	printTable(header, rows)
+	fmt.Println()
+
+	return nil
+}
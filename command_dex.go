@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/aspiringVegetarian/PokedexCLI/internal/pokeapi"
+)
+
+func commandDex(cfg *config, args ...string) error {
+
+	if len(args) == 0 || args[0] != "progress" || len(args) > 2 {
+		return fmt.Errorf("Use dex progress to see every Pokedex, or dex progress <name> for one Pokedex")
+	}
+
+	seen, caught := speciesProgress(cfg)
+
+	if len(args) == 2 {
+		dex, err := cfg.pokeapiClient.GetPokedex(args[1])
+		if err != nil {
+			return err
+		}
+
+		fmt.Println()
+		printDexProgress(dex, seen, caught)
+		fmt.Printf("\nStill missing:")
+		missing := 0
+		for _, entry := range dex.PokemonEntries {
+			name := entry.PokemonSpecies.Name
+			if caught[name] {
+				continue
+			}
+			missing++
+			if seen[name] {
+				fmt.Printf("\n  #%03d %s (seen)", entry.EntryNumber, name)
+			} else {
+				fmt.Printf("\n  #%03d %s", entry.EntryNumber, name)
+			}
+		}
+		if missing == 0 {
+			fmt.Printf("\n  nothing, you caught them all!")
+		}
+		fmt.Printf("\n\n")
+		return nil
+	}
+
+	list, err := cfg.pokeapiClient.ListPokedexes()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	for _, result := range list.Results {
+		dex, err := cfg.pokeapiClient.GetPokedex(result.Name)
+		if err != nil {
+			return err
+		}
+		if !dex.IsMainSeries {
+			continue
+		}
+		printDexProgress(dex, seen, caught)
+	}
+	fmt.Println()
+
+	return nil
+}
+
+// speciesProgress returns the species that have been seen and caught. Pokedex
+// entries list species, which can cover several Pokemon forms.
+func speciesProgress(cfg *config) (map[string]bool, map[string]bool) {
+
+	seen := map[string]bool{}
+	caught := map[string]bool{}
+	for name, info := range cfg.pokedexSeen {
+		seen[info.Species.Name] = true
+		if _, ok := cfg.pokedexCaught[name]; ok {
+			caught[info.Species.Name] = true
+		}
+	}
+	return seen, caught
+}
+
+func printDexProgress(dex pokeapi.SpecificPokedexResp, seen, caught map[string]bool) {
+
+	seenCount, caughtCount := 0, 0
+	for _, entry := range dex.PokemonEntries {
+		if seen[entry.PokemonSpecies.Name] {
+			seenCount++
+		}
+		if caught[entry.PokemonSpecies.Name] {
+			caughtCount++
+		}
+	}
+	total := len(dex.PokemonEntries)
+	fmt.Printf("%s: seen %v/%v (%s), caught %v/%v (%s)\n",
+		dex.Name,
+		seenCount, total, percent(seenCount, total),
+		caughtCount, total, percent(caughtCount, total))
+}
+
+func percent(count, total int) string {
+
+	if total == 0 {
+		return "0%"
+	}
+	return fmt.Sprintf("%.1f%%", float64(count)*100/float64(total))
+}
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aspiringVegetarian/PokedexCLI/internal/pokeapi"
+)
+
+// categoryPageSize matches the page size of the item list endpoint.
+const categoryPageSize = 20
+
+func commandItems(cfg *config, args ...string) error {
+
+	positional, flags, err := parseFlags(args)
+	if err != nil {
+		return err
+	}
+	if err := checkFlags(flags, "category"); err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return fmt.Errorf("The items command only takes --category")
+	}
+
+	if category, ok := flags["category"]; ok {
+		if err := setItemCategory(cfg, category); err != nil {
+			return err
+		}
+	}
+
+	if cfg.itemCategory != "" {
+		// like the full list, start over after the last page
+		offset := cfg.categoryItemOffset + categoryPageSize
+		if offset >= len(cfg.categoryItems) {
+			offset = 0
+		}
+		printCategoryItems(cfg, offset)
+		return nil
+	}
+
+	resp, err := cfg.pokeapiClient.ListItems(cfg.nextItemURL)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Items:")
+	for _, item := range resp.Results {
+		fmt.Printf(" * %s\n", item.Name)
+	}
+
+	cfg.nextItemURL = resp.Next
+	cfg.prevItemURL = resp.Previous
+
+	return nil
+}
+
+func commandItemsb(cfg *config, args ...string) error {
+
+	if cfg.itemCategory != "" {
+		if cfg.categoryItemOffset <= 0 {
+			return fmt.Errorf("You are on the first page. Call items again before using itemsb (items back).")
+		}
+		printCategoryItems(cfg, cfg.categoryItemOffset-categoryPageSize)
+		return nil
+	}
+
+	if cfg.prevItemURL == nil {
+		return fmt.Errorf("You are on the first page. Call items again before using itemsb (items back).")
+	}
+
+	resp, err := cfg.pokeapiClient.ListItems(cfg.prevItemURL)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Items:")
+	for _, item := range resp.Results {
+		fmt.Printf(" * %s\n", item.Name)
+	}
+
+	cfg.nextItemURL = resp.Next
+	cfg.prevItemURL = resp.Previous
+
+	return nil
+}
+
+// setItemCategory makes items and itemsb page through one category, or through
+// every item again for the category all.
+func setItemCategory(cfg *config, category string) error {
+
+	if category == "all" {
+		cfg.itemCategory = ""
+		cfg.categoryItems = nil
+		return nil
+	}
+
+	resp, err := cfg.pokeapiClient.GetItemCategory(category)
+	if err != nil {
+		return err
+	}
+	if len(resp.Items) == 0 {
+		return fmt.Errorf("%s has no items", category)
+	}
+
+	items := []string{}
+	for _, item := range resp.Items {
+		items = append(items, item.Name)
+	}
+	cfg.itemCategory = resp.Name
+	cfg.categoryItems = items
+	cfg.categoryItemOffset = -categoryPageSize
+	return nil
+}
+
+func printCategoryItems(cfg *config, offset int) {
+
+	cfg.categoryItemOffset = offset
+	end := min(offset+categoryPageSize, len(cfg.categoryItems))
+	fmt.Printf("Items in %s:\n", cfg.itemCategory)
+	for _, item := range cfg.categoryItems[offset:end] {
+		fmt.Printf(" * %s\n", item)
+	}
+}
+
+func commandItem(cfg *config, args ...string) error {
+
+	if len(args) != 1 {
+		return fmt.Errorf("Please enter one item name or id after the item command")
+	}
+
+	item, err := cfg.pokeapiClient.GetItem(args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\nName: %s", item.Name)
+	fmt.Printf("\nCategory: %s", item.Category.Name)
+	printItemDetails(cfg, item)
+	fmt.Printf("\n\n")
+
+	return nil
+}
+
+func commandBerries(cfg *config, args ...string) error {
+
+	positional, flags, err := parseFlags(args)
+	if err != nil {
+		return err
+	}
+	if err := checkFlags(flags, "flavor"); err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return fmt.Errorf("The berries command only takes --flavor")
+	}
+
+	if flavor, ok := flags["flavor"]; ok {
+		resp, err := cfg.pokeapiClient.GetBerryFlavor(flavor)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Berries with a %s flavor:\n", resp.Name)
+		for _, berry := range resp.Berries {
+			fmt.Printf(" * %s (potency %v)\n", berry.Berry.Name, berry.Potency)
+		}
+		return nil
+	}
+
+	resp, err := cfg.pokeapiClient.ListBerries(cfg.nextBerryURL)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Berries:")
+	for _, berry := range resp.Results {
+		fmt.Printf(" * %s\n", berry.Name)
+	}
+
+	cfg.nextBerryURL = resp.Next
+	cfg.prevBerryURL = resp.Previous
+
+	return nil
+}
+
+func commandBerriesb(cfg *config, args ...string) error {
+
+	if cfg.prevBerryURL == nil {
+		return fmt.Errorf("You are on the first page. Call berries again before using berriesb (berries back).")
+	}
+
+	resp, err := cfg.pokeapiClient.ListBerries(cfg.prevBerryURL)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Berries:")
+	for _, berry := range resp.Results {
+		fmt.Printf(" * %s\n", berry.Name)
+	}
+
+	cfg.nextBerryURL = resp.Next
+	cfg.prevBerryURL = resp.Previous
+
+	return nil
+}
+
+func commandBerry(cfg *config, args ...string) error {
+
+	if len(args) != 1 {
+		return fmt.Errorf("Please enter one berry name or id after the berry command")
+	}
+
+	berry, err := cfg.pokeapiClient.GetBerry(args[0])
+	if err != nil {
+		return err
+	}
+
+	// cost, effect and fling power belong to the berry's item
+	item, err := cfg.pokeapiClient.GetItem(berry.Item.Name)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\nName: %s", berry.Name)
+	printItemDetails(cfg, item)
+	fmt.Printf("\nFirmness: %s", berry.Firmness.Name)
+	fmt.Printf("\nSize: %v mm", berry.Size)
+	fmt.Printf("\nGrowth time: %v hours per stage", berry.GrowthTime)
+	fmt.Printf("\nMax harvest: %v", berry.MaxHarvest)
+	fmt.Printf("\nNatural Gift: %s, power %v", berry.NaturalGiftType.Name, berry.NaturalGiftPower)
+	fmt.Printf("\nFlavors:")
+	for _, content := range berry.Flavors {
+		if content.Potency > 0 {
+			fmt.Printf("\n  --%s: %v", content.Flavor.Name, content.Potency)
+		}
+	}
+	fmt.Printf("\n\n")
+
+	return nil
+}
+
+// printItemDetails prints the cost, effect and fling power of an item.
+func printItemDetails(cfg *config, item pokeapi.SpecificItemResp) {
+
+	fmt.Printf("\nCost: %v", item.Cost)
+	effect := ""
+	for _, language := range []string{fallbackLanguage, cfg.language} {
+		for _, entry := range item.EffectEntries {
+			if strings.EqualFold(entry.Language.Name, language) {
+				effect = entry.ShortEffect
+			}
+		}
+	}
+	if effect != "" {
+		fmt.Printf("\nEffect: %s", strings.Join(strings.Fields(effect), " "))
+	}
+	fmt.Printf("\nFling power: %s", optionalInt(item.FlingPower))
+	if item.FlingEffect != nil {
+		fmt.Printf(" (%s)", item.FlingEffect.Name)
+	}
+}
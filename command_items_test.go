@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aspiringVegetarian/PokedexCLI/internal/pokeapi"
+)
+
+func TestItemCategoryPaging(t *testing.T) {
+	items := []string{}
+	for i := 0; i < 25; i++ {
+		items = append(items, fmt.Sprintf(`{"name": "tm%02d"}`, i))
+	}
+	client := pokeapi.NewClientWithTransport(time.Minute, stubTransport{
+		"item-category/all-machines": `{"name": "all-machines", "items": [` + strings.Join(items, ",") + `]}`,
+	})
+	cfg := newConfig(client, defaultShinyRate, false, "en")
+	defer cfg.pokeapiClient.Close()
+
+	steps := []struct {
+		command func(*config, ...string) error
+		args    []string
+		offset  int
+		fails   bool
+	}{
+		{command: commandItems, args: []string{"--category", "all-machines"}, offset: 0},
+		{command: commandItems, offset: 20},
+		{command: commandItemsb, offset: 0},
+		{command: commandItemsb, offset: 0, fails: true},
+		{command: commandItems, offset: 20},
+		{command: commandItems, offset: 0},
+	}
+	for i, step := range steps {
+		err := step.command(&cfg, step.args...)
+		if (err != nil) != step.fails {
+			t.Errorf("step %v failure does not match: %v", i, err)
+		}
+		if cfg.categoryItemOffset != step.offset {
+			t.Errorf("step %v offsets do not match: %v vs %v", i, cfg.categoryItemOffset, step.offset)
+		}
+	}
+}
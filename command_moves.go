@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/aspiringVegetarian/PokedexCLI/internal/pokeapi"
+)
+
+// learnMethods are the values accepted by the --method flag of moves.
+var learnMethods = []string{"level-up", "machine", "egg", "tutor"}
+
+func commandMoves(cfg *config, args ...string) error {
+
+	positional, flags, err := parseFlags(args)
+	if err != nil {
+		return err
+	}
+	if err := checkFlags(flags, "version-group", "method"); err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("Please enter one Pokemon name or id after the moves command")
+	}
+	method, filterMethod := flags["method"]
+	if filterMethod {
+		if !slices.Contains(learnMethods, method) {
+			return fmt.Errorf("Unknown learn method %s. Use level-up, machine, egg or tutor", method)
+		}
+	}
+
+	info, err := cfg.pokeapiClient.ExplorePokemon(&positional[0])
+	if err != nil {
+		return err
+	}
+
+	versionGroup, ok := flags["version-group"]
+	if !ok {
+		versionGroup = latestVersionGroup(info)
+	}
+
+	type learnsetEntry struct {
+		move   string
+		method string
+		level  int
+	}
+	learnset := []learnsetEntry{}
+	for _, content := range info.Moves {
+		for _, details := range content.VersionGroupDetails {
+			if details.VersionGroup.Name != versionGroup {
+				continue
+			}
+			if filterMethod && details.MoveLearnMethod.Name != method {
+				continue
+			}
+			learnset = append(learnset, learnsetEntry{
+				move:   content.Move.Name,
+				method: details.MoveLearnMethod.Name,
+				level:  details.LevelLearnedAt,
+			})
+		}
+	}
+	if len(learnset) == 0 {
+		return fmt.Errorf("%s learns no matching moves in %s", info.Name, versionGroup)
+	}
+
+	// level-up moves come first in level order, everything else by method and name
+	sort.Slice(learnset, func(i, j int) bool {
+		a, b := learnset[i], learnset[j]
+		if (a.method == "level-up") != (b.method == "level-up") {
+			return a.method == "level-up"
+		}
+		if a.level != b.level {
+			return a.level < b.level
+		}
+		if a.method != b.method {
+			return a.method < b.method
+		}
+		return a.move < b.move
+	})
+
+	rows := make([]tableRow, len(learnset))
+	for i, entry := range learnset {
+		level := "-"
+		if entry.method == "level-up" {
+			level = fmt.Sprint(entry.level)
+		}
+		rows[i] = tableRow{label: entry.move, cells: []string{level, entry.method}}
+	}
+
+	fmt.Printf("\n%s's moves in %s:\n\n", info.Name, versionGroup)
+	printTable([]string{"level", "method"}, rows)
+	fmt.Println()
+
+	return nil
+}
+
+func commandMove(cfg *config, args ...string) error {
+
+	if len(args) != 1 {
+		return fmt.Errorf("Please enter one move name or id after the move command")
+	}
+
+	move, err := cfg.pokeapiClient.GetMove(args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\nName: %s", move.Name)
+	fmt.Printf("\nType: %s", move.Type.Name)
+	fmt.Printf("\nDamage class: %s", move.DamageClass.Name)
+	fmt.Printf("\nPower: %s", optionalInt(move.Power))
+	fmt.Printf("\nAccuracy: %s", optionalInt(move.Accuracy))
+	fmt.Printf("\nPP: %s", optionalInt(move.Pp))
+	if move.Priority != 0 {
+		fmt.Printf("\nPriority: %+d", move.Priority)
+	}
+	effect := ""
+	for _, language := range []string{fallbackLanguage, cfg.language} {
+		for _, entry := range move.EffectEntries {
+			if strings.EqualFold(entry.Language.Name, language) {
+				effect = entry.ShortEffect
+			}
+		}
+	}
+	if effect != "" {
+		fmt.Printf("\nEffect: %s", moveEffectText(effect, move.EffectChance))
+	}
+	fmt.Printf("\n\n")
+
+	return nil
+}
+
+// latestVersionGroup returns the newest version group the Pokemon has moves in.
+// Version group ids increase with each release, so the highest id is the newest.
+func latestVersionGroup(info pokeapi.SpecificPokemonResp) string {
+
+	latest := ""
+	latestID := 0
+	for _, content := range info.Moves {
+		for _, details := range content.VersionGroupDetails {
+			id, err := pokeapi.ResourceID(details.VersionGroup.URL)
+			if err != nil {
+				continue
+			}
+			if id > latestID {
+				latest = details.VersionGroup.Name
+				latestID = id
+			}
+		}
+	}
+	return latest
+}
+
+// moveEffectText fills in the $effect_chance placeholder used by the API.
+func moveEffectText(effect string, effectChance *int) string {
+
+	if effectChance != nil {
+		effect = strings.ReplaceAll(effect, "$effect_chance", fmt.Sprint(*effectChance))
+	}
+	return strings.Join(strings.Fields(effect), " ")
+}
+
+func optionalInt(value *int) string {
+
+	if value == nil {
+		return "-"
+	}
+	return fmt.Sprint(*value)
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+)
+
+const (
+	defaultPrefetchConcurrency = 8
+	maxPrefetchConcurrency     = 64
+)
+
+// prefetchKinds are the resources prefetch can warm the cache with, in the
+// order "all" fetches them.
+var prefetchKinds = []string{"pokemon", "locations", "species"}
+
+func commandPrefetch(cfg *config, args ...string) error {
+
+	positional, flags, err := parseFlags(args)
+	if err != nil {
+		return err
+	}
+	if err := checkFlags(flags, "concurrency"); err != nil {
+		return err
+	}
+	if len(positional) > 1 {
+		return fmt.Errorf("Please enter one of pokemon, locations, species or all after the prefetch command")
+	}
+
+	concurrency := defaultPrefetchConcurrency
+	if value, ok := flags["concurrency"]; ok {
+		concurrency, err = strconv.Atoi(value)
+		if err != nil || concurrency < 1 || concurrency > maxPrefetchConcurrency {
+			return fmt.Errorf("--concurrency must be a number from 1 to %v", maxPrefetchConcurrency)
+		}
+	}
+
+	kinds := prefetchKinds
+	if len(positional) == 1 && positional[0] != "all" {
+		kinds = []string{positional[0]}
+	}
+
+	for _, kind := range kinds {
+		names, fetch, err := prefetchTarget(cfg, kind)
+		if err != nil {
+			return err
+		}
+		err = runWorkers(len(names), concurrency, "Fetching "+kind, func(i int) error {
+			return fetch(names[i])
+		})
+		if err != nil {
+			return fmt.Errorf("Some %s could not be fetched: %w", kind, err)
+		}
+	}
+
+	if disk, exists := cfg.pokeapiClient.DiskCacheStats(); exists {
+		fmt.Printf("\nSaved to %s, so later sessions can use it without the network.\n\n", disk.Dir)
+	} else {
+		fmt.Printf("\nSaved in memory only. Start with -cache-dir to keep it for later sessions.\n\n")
+	}
+
+	return nil
+}
+
+// prefetchTarget walks every page of the list endpoint for kind and returns the
+// names it lists, along with the function that fetches one of them.
+func prefetchTarget(cfg *config, kind string) ([]string, func(name string) error, error) {
+
+	names := []string{}
+	var pageURL *string
+	switch kind {
+	case "pokemon":
+		for {
+			resp, err := cfg.pokeapiClient.ListPokemon(pageURL)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, result := range resp.Results {
+				names = append(names, result.Name)
+			}
+			if pageURL = resp.Next; pageURL == nil {
+				break
+			}
+		}
+		return names, func(name string) error {
+			_, err := cfg.pokeapiClient.ExplorePokemon(&name)
+			return err
+		}, nil
+
+	case "locations":
+		for {
+			resp, err := cfg.pokeapiClient.ListLocationAreas(pageURL)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, result := range resp.Results {
+				names = append(names, result.Name)
+			}
+			if pageURL = resp.Next; pageURL == nil {
+				break
+			}
+		}
+		return names, func(name string) error {
+			_, err := cfg.pokeapiClient.ExploreLocationArea(&name)
+			return err
+		}, nil
+
+	case "species":
+		for {
+			resp, err := cfg.pokeapiClient.ListPokemonSpecies(pageURL)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, result := range resp.Results {
+				names = append(names, result.Name)
+			}
+			if pageURL = resp.Next; pageURL == nil {
+				break
+			}
+		}
+		return names, func(name string) error {
+			_, err := cfg.pokeapiClient.GetPokemonSpecies(name)
+			return err
+		}, nil
+	}
+
+	return nil, nil, fmt.Errorf("Unknown prefetch target %s. Use pokemon, locations, species or all", kind)
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/aspiringVegetarian/PokedexCLI/internal/pokeapi"
+)
+
+const (
+	// regionFetchWorkers bounds how many locations are fetched at once.
+	regionFetchWorkers = 8
+	regionPageSize     = 20
+)
+
+func commandRegions(cfg *config, args ...string) error {
+
+	resp, err := cfg.pokeapiClient.ListRegions()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Regions:")
+	for _, region := range resp.Results {
+		fmt.Printf(" * %s\n", region.Name)
+	}
+	fmt.Println("Use locations <region> to see the locations and areas in a region, or map --region <region> to page through its areas.")
+
+	return nil
+}
+
+func commandLocations(cfg *config, args ...string) error {
+
+	if len(args) != 1 {
+		return fmt.Errorf("Please enter one region name after the locations command")
+	}
+
+	locations, err := regionLocations(cfg, args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%s\n", args[0])
+	for _, location := range locations {
+		fmt.Printf(" * %s\n", location.Name)
+		for _, area := range location.Areas {
+			fmt.Printf("   --%s\n", area.Name)
+		}
+	}
+	fmt.Println()
+
+	return nil
+}
+
+// regionLocations fetches every location in a region, in the region's order.
+func regionLocations(cfg *config, regionName string) ([]pokeapi.SpecificLocationResp, error) {
+
+	region, err := cfg.pokeapiClient.GetRegion(regionName)
+	if err != nil {
+		return nil, err
+	}
+
+	locations := make([]pokeapi.SpecificLocationResp, len(region.Locations))
+	err = runWorkers(len(region.Locations), regionFetchWorkers, "", func(i int) error {
+		var err error
+		locations[i], err = cfg.pokeapiClient.GetLocation(region.Locations[i].Name)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return locations, nil
+}
+
+// setMapRegion limits map and mapb to the areas of one region, or lifts the
+// limit again for "all".
+func setMapRegion(cfg *config, regionName string) error {
+
+	if regionName == "all" {
+		cfg.mapRegion = ""
+		cfg.regionAreas = nil
+		return nil
+	}
+
+	locations, err := regionLocations(cfg, regionName)
+	if err != nil {
+		return err
+	}
+
+	areas := []string{}
+	for _, location := range locations {
+		for _, area := range location.Areas {
+			areas = append(areas, area.Name)
+		}
+	}
+	if len(areas) == 0 {
+		return fmt.Errorf("%s has no location areas to explore", regionName)
+	}
+
+	cfg.mapRegion = regionName
+	cfg.regionAreas = areas
+	cfg.regionAreaOffset = -regionPageSize
+	return nil
+}
+
+// printRegionAreas shows the page of region areas starting at offset.
+func printRegionAreas(cfg *config, offset int) {
+
+	cfg.regionAreaOffset = offset
+	end := min(offset+regionPageSize, len(cfg.regionAreas))
+	fmt.Printf("Location areas in %s:\n", cfg.mapRegion)
+	for _, area := range cfg.regionAreas[offset:end] {
+		fmt.Printf(" * %s\n", area)
+	}
+}
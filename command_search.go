@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// indexWorkers bounds how many Pokemon are fetched at once while indexing.
+const indexWorkers = 8
+
+func commandSearch(cfg *config, args ...string) error {
+
+	if len(args) == 0 {
+		return fmt.Errorf("Please enter a search query after the search command, such as search type:water bst>500 gen:3")
+	}
+
+	terms, err := parseQuery(args)
+	if err != nil {
+		return err
+	}
+
+	// every Pokemon in the Pokedex is searchable without building the full index
+	for name := range cfg.pokedexSeen {
+		if _, indexed := cfg.searchIndex[name]; indexed {
+			continue
+		}
+		entry, err := indexPokemon(cfg, name)
+		if err != nil {
+			return err
+		}
+		cfg.searchIndex[name] = entry
+	}
+
+	results := []searchEntry{}
+	for _, entry := range cfg.searchIndex {
+		if entry.matches(terms) {
+			results = append(results, entry)
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].id != results[j].id {
+			return results[i].id < results[j].id
+		}
+		return results[i].name < results[j].name
+	})
+
+	fmt.Printf("\nFound %v of %v indexed Pokemon:", len(results), len(cfg.searchIndex))
+	for _, entry := range results {
+		fmt.Printf("\n * #%03d %s (%s, bst %v)", entry.id, entry.name, strings.Join(entry.types, "/"), entry.numbers["bst"])
+	}
+	if cfg.searchIndexComplete {
+		fmt.Printf("\n\n")
+	} else {
+		fmt.Printf("\n\nOnly Pokemon in your Pokedex are searched until you build the full index with the index command.\n\n")
+	}
+
+	return nil
+}
+
+func commandIndex(cfg *config, args ...string) error {
+
+	names := []string{}
+	var pageURL *string
+	for {
+		resp, err := cfg.pokeapiClient.ListPokemon(pageURL)
+		if err != nil {
+			return err
+		}
+		for _, result := range resp.Results {
+			if _, indexed := cfg.searchIndex[result.Name]; !indexed {
+				names = append(names, result.Name)
+			}
+		}
+		if resp.Next == nil {
+			break
+		}
+		pageURL = resp.Next
+	}
+
+	entries := make([]searchEntry, len(names))
+	errs := make([]error, len(names))
+	err := runWorkers(len(names), indexWorkers, "Indexing", func(i int) error {
+		entries[i], errs[i] = indexPokemon(cfg, names[i])
+		return errs[i]
+	})
+
+	for i, entry := range entries {
+		if errs[i] == nil {
+			cfg.searchIndex[names[i]] = entry
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("Some Pokemon could not be indexed: %w", err)
+	}
+	cfg.searchIndexComplete = true
+
+	fmt.Printf("The search index now covers %v Pokemon.\n", len(cfg.searchIndex))
+	return nil
+}
+
+// indexPokemon builds the search entry for a Pokemon from its /pokemon and
+// /pokemon-species data, both of which come from the cache when possible.
+func indexPokemon(cfg *config, name string) (searchEntry, error) {
+
+	info, err := cfg.pokeapiClient.ExplorePokemon(&name)
+	if err != nil {
+		return searchEntry{}, err
+	}
+	species, err := cfg.pokeapiClient.GetPokemonSpecies(info.Species.Name)
+	if err != nil {
+		return searchEntry{}, err
+	}
+	return newSearchEntry(info, species), nil
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/aspiringVegetarian/PokedexCLI/internal/pokeapi"
+)
+
+// maxTeamSuggestions caps how many Pokedex entries are suggested to fill gaps.
+const maxTeamSuggestions = 3
+
+func commandTeamAnalyze(cfg *config) error {
+
+	if len(cfg.pokedexCaught) == 0 {
+		fmt.Printf("\nYou haven't caught any Pokemon yet! Get out there!\n\n")
+		return nil
+	}
+
+	types, err := battleTypes(cfg)
+	if err != nil {
+		return err
+	}
+
+	team := []pokeapi.SpecificPokemonResp{}
+	for name := range cfg.pokedexCaught {
+		team = append(team, cfg.pokedexSeen[name])
+	}
+	sort.Slice(team, func(i, j int) bool {
+		return team[i].Name < team[j].Name
+	})
+
+	// the team can attack with its own types and with the types of its damaging moves
+	coverage := map[string]bool{}
+	for _, info := range team {
+		for _, name := range pokemonTypeNames(info) {
+			coverage[name] = true
+		}
+		for _, moveName := range cfg.pokedexCaught[info.Name].moves {
+			move, err := cfg.pokeapiClient.GetMove(moveName)
+			if err != nil {
+				return err
+			}
+			if move.DamageClass.Name != "status" {
+				coverage[move.Type.Name] = true
+			}
+		}
+	}
+
+	weaknesses := sharedWeaknesses(types, team)
+	sharedWeaknessLines := []string{}
+	for _, attack := range weaknesses {
+		weak := []string{}
+		for _, info := range team {
+			if damageMultiplier(attack, pokemonTypeNames(info)) > 1 {
+				weak = append(weak, info.Name)
+			}
+		}
+		sharedWeaknessLines = append(sharedWeaknessLines, fmt.Sprintf("%s (%s)", attack.Name, strings.Join(weak, ", ")))
+	}
+
+	immunities := []string{}
+	for _, attack := range types {
+		immune := []string{}
+		for _, info := range team {
+			if damageMultiplier(attack, pokemonTypeNames(info)) == 0 {
+				immune = append(immune, info.Name)
+			}
+		}
+		if len(immune) > 0 {
+			immunities = append(immunities, fmt.Sprintf("%s (%s)", attack.Name, strings.Join(immune, ", ")))
+		}
+	}
+
+	uncovered := []string{}
+	for _, defender := range types {
+		covered := false
+		for _, attack := range types {
+			if coverage[attack.Name] && damageMultiplier(attack, []string{defender.Name}) > 1 {
+				covered = true
+			}
+		}
+		if !covered {
+			uncovered = append(uncovered, defender.Name)
+		}
+	}
+
+	fmt.Printf("\nShared weaknesses:")
+	printAnalysisGroup(sharedWeaknessLines)
+	fmt.Printf("\n\nTypes your team can't hit super effectively:")
+	printAnalysisGroup(uncovered)
+	fmt.Printf("\n\nImmunities:")
+	printAnalysisGroup(immunities)
+
+	suggestions := suggestTeamMembers(cfg, types, weaknesses, uncovered)
+	fmt.Printf("\n\nPokemon from your Pokedex that would fill the gaps:")
+	printAnalysisGroup(suggestions)
+	fmt.Printf("\n\n")
+
+	return nil
+}
+
+// sharedWeaknesses returns the attacking types that at least two team members
+// are weak to and that more of the team is weak to than resists.
+func sharedWeaknesses(types []pokeapi.SpecificTypeResp, team []pokeapi.SpecificPokemonResp) []pokeapi.SpecificTypeResp {
+
+	shared := []pokeapi.SpecificTypeResp{}
+	for _, attack := range types {
+		weak := 0
+		resist := 0
+		for _, info := range team {
+			multiplier := damageMultiplier(attack, pokemonTypeNames(info))
+			if multiplier > 1 {
+				weak++
+			} else if multiplier < 1 {
+				resist++
+			}
+		}
+		if weak >= 2 && weak > resist {
+			shared = append(shared, attack)
+		}
+	}
+	return shared
+}
+
+// suggestTeamMembers scores the Pokemon that have been seen but not caught by
+// how many shared weaknesses they resist and uncovered types they hit hard.
+func suggestTeamMembers(cfg *config, types, weaknesses []pokeapi.SpecificTypeResp, uncovered []string) []string {
+
+	type suggestion struct {
+		name   string
+		score  int
+		reason []string
+	}
+	suggestions := []suggestion{}
+	for name, info := range cfg.pokedexSeen {
+		if _, caught := cfg.pokedexCaught[name]; caught {
+			continue
+		}
+		candidateTypes := pokemonTypeNames(info)
+		s := suggestion{name: name}
+		for _, attack := range weaknesses {
+			if damageMultiplier(attack, candidateTypes) < 1 {
+				s.score++
+				s.reason = append(s.reason, "resists "+attack.Name)
+			}
+		}
+		for _, defender := range uncovered {
+			for _, attack := range types {
+				if slices.Contains(candidateTypes, attack.Name) && damageMultiplier(attack, []string{defender}) > 1 {
+					s.score++
+					s.reason = append(s.reason, "hits "+defender)
+					break
+				}
+			}
+		}
+		if s.score > 0 {
+			suggestions = append(suggestions, s)
+		}
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].score != suggestions[j].score {
+			return suggestions[i].score > suggestions[j].score
+		}
+		return suggestions[i].name < suggestions[j].name
+	})
+
+	output := []string{}
+	for _, s := range suggestions[:min(len(suggestions), maxTeamSuggestions)] {
+		output = append(output, fmt.Sprintf("%s (%s)", s.name, strings.Join(s.reason, ", ")))
+	}
+	return output
+}
+
+func printAnalysisGroup(lines []string) {
+
+	if len(lines) == 0 {
+		fmt.Printf("\n  none")
+	}
+	for _, line := range lines {
+		fmt.Printf("\n * %s", line)
+	}
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aspiringVegetarian/PokedexCLI/internal/pokeapi"
+)
+
+// defensiveMultipliers are the multipliers a defending type combination can
+// take, strongest first.
+var defensiveMultipliers = []float64{4, 2, 0.5, 0.25, 0}
+
+func commandType(cfg *config, args ...string) error {
+
+	if len(args) == 0 || len(args) > 2 {
+		return fmt.Errorf("Please enter one type, or two types for a dual-type Pokemon, after the type command")
+	}
+
+	fmt.Printf("\n%s\n", strings.Join(args, "/"))
+
+	fmt.Printf("\nAttacking:")
+	for _, name := range args {
+		info, err := cfg.pokeapiClient.GetType(name)
+		if err != nil {
+			return err
+		}
+		relations := info.DamageRelations
+		fmt.Printf("\n  %s moves", info.Name)
+		printTypeGroup("2x", relationNames(relations.DoubleDamageTo))
+		printTypeGroup("0.5x", relationNames(relations.HalfDamageTo))
+		printTypeGroup("0x", relationNames(relations.NoDamageTo))
+	}
+
+	attackTypes, err := battleTypes(cfg)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\n\nDefending:")
+	for _, multiplier := range defensiveMultipliers {
+		group := []string{}
+		for _, attack := range attackTypes {
+			if damageMultiplier(attack, args) == multiplier {
+				group = append(group, attack.Name)
+			}
+		}
+		printTypeGroup(formatMultiplier(multiplier), group)
+	}
+
+	fmt.Printf("\n\nPokemon in your Pokedex with this typing:")
+	found := []string{}
+	for name, info := range cfg.pokedexSeen {
+		if hasTypes(info, args) {
+			found = append(found, name)
+		}
+	}
+	sort.Strings(found)
+	if len(found) == 0 {
+		fmt.Printf("\n  none yet")
+	}
+	for _, name := range found {
+		fmt.Printf("\n * %s", name)
+	}
+	fmt.Printf("\n\n")
+
+	return nil
+}
+
+func commandTypechart(cfg *config, args ...string) error {
+
+	types, err := battleTypes(cfg)
+	if err != nil {
+		return err
+	}
+
+	header := make([]string, len(types))
+	for i, info := range types {
+		header[i] = abbreviateType(info.Name)
+	}
+
+	rows := make([]tableRow, len(types))
+	for i, attack := range types {
+		rows[i] = tableRow{label: attack.Name, cells: make([]string, len(types))}
+		for j, defender := range types {
+			switch damageMultiplier(attack, []string{defender.Name}) {
+			case 2:
+				rows[i].cells[j] = "2"
+			case 0.5:
+				rows[i].cells[j] = ".5"
+			case 0:
+				rows[i].cells[j] = "0"
+			default:
+				rows[i].cells[j] = "."
+			}
+		}
+	}
+
+	fmt.Println("\nAttacking type (rows) against defending type (columns):")
+	fmt.Println()
+	printTable(header, rows)
+	fmt.Println()
+
+	return nil
+}
+
+// battleTypes returns every type that takes part in battles. The API also
+// lists types such as "unknown" and "shadow" which have no damage relations.
+func battleTypes(cfg *config) ([]pokeapi.SpecificTypeResp, error) {
+
+	list, err := cfg.pokeapiClient.ListTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	types := []pokeapi.SpecificTypeResp{}
+	for _, result := range list.Results {
+		info, err := cfg.pokeapiClient.GetType(result.Name)
+		if err != nil {
+			return nil, err
+		}
+		relations := info.DamageRelations
+		if len(relations.DoubleDamageTo)+len(relations.HalfDamageTo)+len(relations.NoDamageTo) == 0 {
+			continue
+		}
+		types = append(types, info)
+	}
+	return types, nil
+}
+
+func relationNames(relations []struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}) []string {
+
+	names := make([]string, 0, len(relations))
+	for _, relation := range relations {
+		names = append(names, relation.Name)
+	}
+	return names
+}
+
+func printTypeGroup(label string, names []string) {
+
+	if len(names) == 0 {
+		return
+	}
+	fmt.Printf("\n  --%s: %s", label, strings.Join(names, ", "))
+}
+
+// hasTypes reports whether the Pokemon has every one of the given types.
+func hasTypes(info pokeapi.SpecificPokemonResp, types []string) bool {
+
+	owned := pokemonTypeNames(info)
+	for _, want := range types {
+		found := false
+		for _, name := range owned {
+			if name == want {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// abbreviateType shortens a type name to three letters so the chart fits in a
+// terminal. The first three letters are unique across all types.
+func abbreviateType(name string) string {
+
+	if len(name) <= 3 {
+		return name
+	}
+	return name[:3]
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+func commandWhere(cfg *config, args ...string) error {
+
+	positional, flags, err := parseFlags(args)
+	if err != nil {
+		return err
+	}
+	if err := checkFlags(flags, "version"); err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("Please enter one Pokemon name or id after the where command")
+	}
+	version, filterVersion := flags["version"]
+
+	encounters, err := cfg.pokeapiClient.ListPokemonEncounters(positional[0])
+	if err != nil {
+		return err
+	}
+
+	// combine the encounter slots of each method into one level range and chance
+	type encounterSummary struct {
+		version  string
+		method   string
+		minLevel int
+		maxLevel int
+		chance   int
+	}
+
+	found := false
+	fmt.Printf("\nWhere to find %s:", positional[0])
+	for _, encounter := range encounters {
+		summaries := []*encounterSummary{}
+		byKey := map[string]*encounterSummary{}
+		for _, versionDetails := range encounter.VersionDetails {
+			if filterVersion && versionDetails.Version.Name != version {
+				continue
+			}
+			for _, details := range versionDetails.EncounterDetails {
+				key := versionDetails.Version.Name + "/" + details.Method.Name
+				summary, exists := byKey[key]
+				if !exists {
+					summary = &encounterSummary{
+						version:  versionDetails.Version.Name,
+						method:   details.Method.Name,
+						minLevel: details.MinLevel,
+						maxLevel: details.MaxLevel,
+					}
+					byKey[key] = summary
+					summaries = append(summaries, summary)
+				}
+				summary.minLevel = min(summary.minLevel, details.MinLevel)
+				summary.maxLevel = max(summary.maxLevel, details.MaxLevel)
+				summary.chance += details.Chance
+			}
+		}
+		if len(summaries) == 0 {
+			continue
+		}
+		sort.SliceStable(summaries, func(i, j int) bool {
+			return summaries[i].version < summaries[j].version
+		})
+
+		found = true
+		fmt.Printf("\n * %s", encounter.LocationArea.Name)
+		for _, summary := range summaries {
+			levels := fmt.Sprintf("level %v", summary.minLevel)
+			if summary.maxLevel != summary.minLevel {
+				levels = fmt.Sprintf("levels %v-%v", summary.minLevel, summary.maxLevel)
+			}
+			fmt.Printf("\n   --%s: %s, %s, %v%% chance", summary.version, summary.method, levels, min(summary.chance, 100))
+		}
+	}
+	if !found {
+		if filterVersion {
+			fmt.Printf("\n  %s can't be found in the wild in %s.", positional[0], version)
+		} else {
+			fmt.Printf("\n  %s can't be found in the wild.", positional[0])
+		}
+	}
+	fmt.Printf("\n\n")
+
+	return nil
+}
@@ -4,12 +4,21 @@ import (
 	"fmt"
 	"math/rand"
 	"os"
+	"strings"
+
+	"github.com/aspiringVegetarian/PokedexCLI/internal/pokeapi"
+	"github.com/aspiringVegetarian/PokedexCLI/internal/sprite"
 )
 
+// spriteWidth is the widest a sprite is drawn, in terminal columns.
+const spriteWidth = 48
+
 type cliCommand struct {
 	name        string
 	description string
-	callback    func(*config) error
+	callback    func(*config, ...string) error
+	// keepCase passes the arguments as typed instead of lowercased
+	keepCase bool
 }
 
 func loadCommands() map[string]cliCommand {
@@ -29,7 +38,8 @@ func loadCommands() map[string]cliCommand {
 			name: "map",
 			description: "Explore locations that can be visited within the Pokemon games.\n" +
 				"     Each call of the command displays the names of 20 location areas in the Pokemon world.\n" +
-				"     Each subsequent call to the command will display the next 20 locations.",
+				"     Each subsequent call to the command will display the next 20 locations.\n" +
+				"     Use --region to only show the areas of one region, and --region all to show every area again.",
 			callback: commandMap,
 		},
 		"mapb": {
@@ -37,6 +47,16 @@ func loadCommands() map[string]cliCommand {
 			description: "Explore the last 20 locations shown by the map command.",
 			callback:    commandMapb,
 		},
+		"regions": {
+			name:        "regions",
+			description: "Lists the regions of the Pokemon world.",
+			callback:    commandRegions,
+		},
+		"locations": {
+			name:        "locations",
+			description: "Lists every location in a region along with the areas inside it. Pass in a region name following the command.",
+			callback:    commandLocations,
+		},
 		"explore": {
 			name:        "explore",
 			description: "Explore a specific location. Pass in a valid location name or id following the command, or it will select a random location.",
@@ -54,6 +74,12 @@ func loadCommands() map[string]cliCommand {
 			description: "Explore the last 20 Pokemon shown by the pokemon command.",
 			callback:    commandPokemonb,
 		},
+		"where": {
+			name: "where",
+			description: "List every location area where a Pokemon can be found, with the encounter method, levels and chance.\n" +
+				"       Pass in a Pokemon name or id following the command, and optionally --version to pick a game.",
+			callback: commandWhere,
+		},
 		"catch": {
 			name:        "catch",
 			description: "Attempt to catch a specific Pokemon. Pass in a valid Pokemon name or id following the command, or it will try to catch a random Pokemon.",
@@ -62,19 +88,132 @@ func loadCommands() map[string]cliCommand {
 		"pokedex": {
 			name: "pokedex",
 			description: "Show information for any Pokemon in your Pokedex (must have encountered via catch command).\n" +
-				"         Provide a Pokemon name following the command.\n" +
-				"         If you do not provide a name following the command, all of the Pokemon in your Pokedex will be listed.",
+				"         Provide a Pokemon name, or the nickname of a caught Pokemon, following the command.\n" +
+				"         If you do not provide a name following the command, all of the Pokemon in your Pokedex will be listed.\n" +
+				"         The list can be ordered with --sort id|name|caught-at|type|bst and filtered with --type <type> and --caught.",
 			callback: commandPokedex,
 		},
+		"compare": {
+			name: "compare",
+			description: "Compare two or more Pokemon side by side. Pass in the Pokemon names or ids following the command.\n" +
+				"         Shows base stats, types, abilities, size and how effective each Pokemon's types are against the others.",
+			callback: commandCompare,
+		},
+		"moves": {
+			name: "moves",
+			description: "List the moves a Pokemon can learn, sorted by level. Pass in a Pokemon name or id following the command.\n" +
+				"       Use --version-group to pick a game (defaults to the newest) and --method level-up|machine|egg|tutor to filter.",
+			callback: commandMoves,
+		},
+		"move": {
+			name:        "move",
+			description: "Show the power, accuracy, PP, type, damage class and effect of a move. Pass in a move name or id following the command.",
+			callback:    commandMove,
+		},
+		"ability": {
+			name:        "ability",
+			description: "Show what an ability does and every Pokemon that can have it. Pass in an ability name or id following the command.",
+			callback:    commandAbility,
+		},
+		"items": {
+			name: "items",
+			description: "Browse the items in the Pokemon games, 20 at a time. Each subsequent call displays the next 20 items.\n" +
+				"       Use --category to browse only the items in one category, and --category all to go back to every item.",
+			callback: commandItems,
+		},
+		"itemsb": {
+			name:        "itemsb",
+			description: "Browse the last 20 items shown by the items command.",
+			callback:    commandItemsb,
+		},
+		"item": {
+			name:        "item",
+			description: "Show the cost, effect and fling power of an item. Pass in an item name or id following the command.",
+			callback:    commandItem,
+		},
+		"berries": {
+			name: "berries",
+			description: "Browse the berries in the Pokemon games, 20 at a time. Each subsequent call displays the next 20 berries.\n" +
+				"         Use --flavor to list every berry with that flavor instead.",
+			callback: commandBerries,
+		},
+		"berriesb": {
+			name:        "berriesb",
+			description: "Browse the last 20 berries shown by the berries command.",
+			callback:    commandBerriesb,
+		},
+		"berry": {
+			name:        "berry",
+			description: "Show the cost, effect, fling power, flavors and growth of a berry. Pass in a berry name or id following the command.",
+			callback:    commandBerry,
+		},
+		"bag": {
+			name:        "bag",
+			description: "Lists the items in your bag. Items held by wild Pokemon are put in your bag when you catch them.",
+			callback:    commandBag,
+		},
+		"give": {
+			name:        "give",
+			description: "Give an item from your bag to a Pokemon on your team. Pass in the Pokemon's name or nickname and the item following the command.",
+			callback:    commandGive,
+		},
+		"take": {
+			name:        "take",
+			description: "Take the held item from a Pokemon on your team and put it in your bag. Pass in the Pokemon's name or nickname following the command.",
+			callback:    commandTake,
+		},
+		"type": {
+			name: "type",
+			description: "Show the strengths and weaknesses of a type. Pass in one type, or two for a dual-type combination, following the command.\n" +
+				"      Also lists the Pokemon in your Pokedex with that typing.",
+			callback: commandType,
+		},
+		"typechart": {
+			name:        "typechart",
+			description: "Show the damage multiplier of every attacking type against every defending type.",
+			callback:    commandTypechart,
+		},
+		"dex": {
+			name: "dex",
+			description: "Use dex progress to see how many Pokemon you have seen and caught in every regional Pokedex.\n" +
+				"     Use dex progress <name>, such as kanto or original-johto, to also list the entries you are still missing.",
+			callback: commandDex,
+		},
+		"search": {
+			name: "search",
+			description: "Search Pokemon by their attributes, for example search type:water bst>500 gen:3 ability:swift-swim\n" +
+				"        Keys: name, type, ability, color, legendary, mythical, id, gen, bst, height, weight and each base stat.\n" +
+				"        Numbers can be compared with :, =, >, <, >= and <=. Start a term with - to exclude matches.",
+			callback: commandSearch,
+		},
+		"index": {
+			name:        "index",
+			description: "Build the search index for every Pokemon, so search looks beyond the Pokemon in your Pokedex.",
+			callback:    commandIndex,
+		},
+		"prefetch": {
+			name: "prefetch",
+			description: "Download every Pokemon, location area and/or species into the cache so later commands are instant.\n" +
+				"          Pass in pokemon, locations, species or all (the default), and optionally --concurrency n (default 8).",
+			callback: commandPrefetch,
+		},
+		"cache": {
+			name: "cache",
+			description: "Inspect and manage the cache of PokeAPI responses.\n" +
+				"       Use cache stats, cache list, cache clear or cache purge <url>.",
+			callback: commandCache,
+			keepCase: true,
+		},
 		"team": {
-			name:        "team",
-			description: "Lists the Pokemon you have caught.",
-			callback:    commandTeam,
+			name: "team",
+			description: "Lists the Pokemon you have caught, with the same --sort and --type options as pokedex. Use team analyze to see the team's shared weaknesses,\n" +
+				"      uncovered types, immunities and Pokemon from your Pokedex that would fill the gaps.",
+			callback: commandTeam,
 		},
 	}
 }
 
-func commandHelp(cfg *config) error {
+func commandHelp(cfg *config, args ...string) error {
 
 	fmt.Println()
 	fmt.Println("Welcome to the Pokedex!")
@@ -87,14 +226,41 @@ func commandHelp(cfg *config) error {
 	return nil
 }
 
-func commandExit(cfg *config) error {
+func commandExit(cfg *config, args ...string) error {
 
 	fmt.Println("Thank you for using PokedexCLI! See you soon")
+	cfg.pokeapiClient.Close()
 	os.Exit(0)
 	return nil
 }
 
-func commandMap(cfg *config) error {
+func commandMap(cfg *config, args ...string) error {
+
+	positional, flags, err := parseFlags(args)
+	if err != nil {
+		return err
+	}
+	if err := checkFlags(flags, "region"); err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return fmt.Errorf("The map command only takes --region")
+	}
+	if regionName, ok := flags["region"]; ok {
+		if err := setMapRegion(cfg, regionName); err != nil {
+			return err
+		}
+	}
+
+	if cfg.mapRegion != "" {
+		// like the full map, start over after the last page
+		offset := cfg.regionAreaOffset + regionPageSize
+		if offset >= len(cfg.regionAreas) {
+			offset = 0
+		}
+		printRegionAreas(cfg, offset)
+		return nil
+	}
 
 	resp, err := cfg.pokeapiClient.ListLocationAreas(cfg.nextLocationAreaURL)
 	if err != nil {
@@ -115,7 +281,15 @@ func commandMap(cfg *config) error {
 	return nil
 }
 
-func commandMapb(cfg *config) error {
+func commandMapb(cfg *config, args ...string) error {
+
+	if cfg.mapRegion != "" {
+		if cfg.regionAreaOffset <= 0 {
+			return fmt.Errorf("You are on the first page. Call map again before using mapb (map back).")
+		}
+		printRegionAreas(cfg, cfg.regionAreaOffset-regionPageSize)
+		return nil
+	}
 
 	if cfg.prevLocationAreaURL == nil {
 		return fmt.Errorf("You are on the first page. Call map again before using mapb (map back).")
@@ -140,21 +314,29 @@ func commandMapb(cfg *config) error {
 	return nil
 }
 
-func commandExplore(cfg *config) error {
-	if cfg.locationCount == nil && cfg.specificLocation == nil {
+func commandExplore(cfg *config, args ...string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("Only enter one location id or name after the explore command")
+	}
+	var specificLocation *string
+	if len(args) == 1 {
+		specificLocation = &args[0]
+	}
+
+	if cfg.locationCount == nil && specificLocation == nil {
 		resp, err := cfg.pokeapiClient.ListLocationAreas(cfg.nextLocationAreaURL)
 		if err != nil {
 			return err
 		}
 		cfg.locationCount = resp.Count
 	}
-	if cfg.specificLocation == nil {
+	if specificLocation == nil {
 		randomIDString := fmt.Sprint(rand.Intn(*cfg.locationCount-1) + 1)
-		cfg.specificLocation = &randomIDString
+		specificLocation = &randomIDString
 
 	}
 
-	resp, err := cfg.pokeapiClient.ExploreLocationArea(cfg.specificLocation)
+	resp, err := cfg.pokeapiClient.ExploreLocationArea(specificLocation)
 	if err != nil {
 		return err
 	}
@@ -170,7 +352,7 @@ func commandExplore(cfg *config) error {
 	}
 	return nil
 }
-func commandPokemon(cfg *config) error {
+func commandPokemon(cfg *config, args ...string) error {
 
 	resp, err := cfg.pokeapiClient.ListPokemon(cfg.nextPokemonURL)
 	if err != nil {
@@ -191,7 +373,7 @@ func commandPokemon(cfg *config) error {
 	return nil
 }
 
-func commandPokemonb(cfg *config) error {
+func commandPokemonb(cfg *config, args ...string) error {
 
 	if cfg.prevPokemonURL == nil {
 		return fmt.Errorf("You are on the first page. Call pokemon again before using pokemonb (pokemon back).")
@@ -216,34 +398,50 @@ func commandPokemonb(cfg *config) error {
 	return nil
 }
 
-func commandCatch(cfg *config) error {
-	if cfg.pokemonCount == nil && cfg.specificPokemon == nil {
+func commandCatch(cfg *config, args ...string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("Please enter only one Pokemon id or name after the catch command")
+	}
+	var specificPokemon *string
+	if len(args) == 1 {
+		specificPokemon = &args[0]
+	}
+
+	if cfg.pokemonCount == nil && specificPokemon == nil {
 		resp, err := cfg.pokeapiClient.ListPokemon(cfg.nextPokemonURL)
 		if err != nil {
 			return err
 		}
 		cfg.pokemonCount = resp.Count
 	}
-	if cfg.specificPokemon == nil {
+	if specificPokemon == nil {
 		randomID := rand.Intn(*cfg.pokemonCount-1) + 1
 		if randomID > 1025 {
 			randomID = 10000 + (randomID - 1025)
 		}
 		randomIDString := fmt.Sprint(randomID)
-		cfg.specificPokemon = &randomIDString
+		specificPokemon = &randomIDString
 
 	}
 
-	resp, err := cfg.pokeapiClient.ExplorePokemon(cfg.specificPokemon)
+	resp, err := cfg.pokeapiClient.ExplorePokemon(specificPokemon)
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("\nYou see a wild %s!\n", resp.Name)
+	shiny := rand.Intn(cfg.shinyRate) == 0
+	if shiny {
+		cfg.shinySeen[resp.Name]++
+		fmt.Printf("\nYou see a wild %s! It's shiny!\n", resp.Name)
+	} else {
+		fmt.Printf("\nYou see a wild %s!\n", resp.Name)
+	}
+	printSprite(cfg, resp, shiny)
+	heldItem := rollHeldItem(resp)
 
-	givenName, caught := cfg.pokedexCaught[resp.Name]
+	owned, caught := cfg.pokedexCaught[resp.Name]
 	if caught {
-		fmt.Printf("\nYou have already caught a %s and named it %s, so you let this one go...\n\n", resp.Name, givenName)
+		fmt.Printf("\nYou have already caught a %s and named it %s, so you let this one go...\n\n", resp.Name, owned.nickname)
 		return nil
 	}
 
@@ -252,6 +450,9 @@ func commandCatch(cfg *config) error {
 		fmt.Printf("\nIt won't get away this time!\n")
 	}
 
+	// roll the nature up front, so a failed request cannot cost the catch
+	nature := randomNature(cfg)
+
 	fmt.Printf("\nYou throw a PokeBall at %s...\n", resp.Name)
 
 	catchDifficulty := (resp.BaseExperience / 2) * (resp.Height / 4) * (resp.Weight / 4)
@@ -279,13 +480,23 @@ func commandCatch(cfg *config) error {
 		if newName == "" {
 			newName = resp.Name
 		}
-		cfg.pokedexCaught[resp.Name] = newName
+		// catching a Pokemon counts as defeating it, so the team earns its EVs
+		for _, member := range cfg.pokedexCaught {
+			member.gainEVs(resp)
+		}
+		owned := newOwnedPokemon(resp, newName, nature)
+		owned.shiny = shiny
+		cfg.pokedexCaught[resp.Name] = owned
 		if !seen {
 			fmt.Printf("\n%s has been added to your team and %s has been added to your Pokedex!\n\n", newName, resp.Name)
 		} else {
 			fmt.Printf("\n%s was added to your team!\n\n", newName)
 		}
 		cfg.pokedexSeen[resp.Name] = resp
+		if heldItem != "" {
+			cfg.inventory[heldItem]++
+			fmt.Printf("%s was holding a %s. It has been put in your bag.\n\n", newName, heldItem)
+		}
 
 	} else {
 		// GOT AWAY
@@ -301,27 +512,56 @@ func commandCatch(cfg *config) error {
 	return nil
 }
 
-func commandPokedex(cfg *config) error {
+func commandPokedex(cfg *config, args ...string) error {
 
-	if cfg.specificPokemon == nil {
-		//k := rand.Intn(len(cfg.pokedexSeen))
+	args, flags, err := parseFlags(args, "caught")
+	if err != nil {
+		return err
+	}
+	if err := checkFlags(flags, "sort", "type", "caught"); err != nil {
+		return err
+	}
+	if len(args) > 1 {
+		return fmt.Errorf("Please enter only one Pokemon name after the pokedex command")
+	}
+
+	if len(args) == 0 {
+		entries, err := listEntries(cfg, flags)
+		if err != nil {
+			return err
+		}
 		fmt.Printf("\nYou have the following Pokemon in your Pokedex: ")
-		for name, _ := range cfg.pokedexSeen {
-			fmt.Printf("\n * %s", name)
+		for _, entry := range entries {
+			if entry.owned != nil {
+				fmt.Printf("\n * #%03d %s (caught)", entry.dexNumber, entry.name)
+			} else {
+				fmt.Printf("\n * #%03d %s", entry.dexNumber, entry.name)
+			}
 		}
 		fmt.Printf("\n\nUse the pokedex command with any of the Pokemon names listed to see more info.\n")
 		return nil
 	} else {
-		info := cfg.pokedexSeen[*cfg.specificPokemon]
-		givenName, caught := cfg.pokedexCaught[info.Name]
+		info, seen := cfg.pokedexSeen[args[0]]
+		if !seen {
+			owned := findByNickname(cfg, args[0])
+			if owned == nil {
+				return fmt.Errorf("%s is not in your Pokedex. Try catching it first!", args[0])
+			}
+			info = cfg.pokedexSeen[owned.species]
+		}
+		owned, caught := cfg.pokedexCaught[info.Name]
+		printSprite(cfg, info, caught && owned.shiny)
 		if caught {
-			if givenName != info.Name {
-				fmt.Printf("\nYou have caught a %s and named it %s!\n", info.Name, givenName)
+			if owned.nickname != info.Name {
+				fmt.Printf("\nYou have caught a %s and named it %s!\n", info.Name, owned.nickname)
 			} else {
 				fmt.Printf("\nYou have caught a %s!\n", info.Name)
 			}
 		}
 		fmt.Printf("\nName: %s", info.Name)
+		if shinyCount := cfg.shinySeen[info.Name]; shinyCount > 0 {
+			fmt.Printf("\nShiny encounters: %v", shinyCount)
+		}
 		fmt.Printf("\nHeight: %v", info.Height)
 		fmt.Printf("\nWeight: %v", info.Weight)
 		fmt.Printf("\nStats:")
@@ -332,6 +572,29 @@ func commandPokedex(cfg *config) error {
 		for _, content := range info.Types {
 			fmt.Printf("\n  --%s", content.Type.Name)
 		}
+		fmt.Printf("\nAbilities:")
+		for _, content := range info.Abilities {
+			if content.IsHidden {
+				fmt.Printf("\n  --%s (hidden)", content.Ability.Name)
+			} else {
+				fmt.Printf("\n  --%s", content.Ability.Name)
+			}
+		}
+		if caught {
+			fmt.Printf("\n\n%s's stats (level %v, %s nature):", owned.nickname, owned.level, owned.nature)
+			for _, content := range info.Stats {
+				stat := content.Stat.Name
+				fmt.Printf("\n  --%s: %v (IV %v, EV %v)", stat, owned.actualStat(stat, content.BaseStat), owned.ivs[stat], owned.evs[stat])
+			}
+			if len(owned.moves) == 0 {
+				fmt.Printf("\n%s's moves: none", owned.nickname)
+			} else {
+				fmt.Printf("\n%s's moves:", owned.nickname)
+			}
+			for _, move := range owned.moves {
+				fmt.Printf("\n  --%s", move)
+			}
+		}
 		fmt.Printf("\n\n")
 
 		return nil
@@ -339,20 +602,104 @@ func commandPokedex(cfg *config) error {
 	}
 }
 
-func commandTeam(cfg *config) error {
+func commandTeam(cfg *config, args ...string) error {
+	if len(args) == 1 && args[0] == "analyze" {
+		return commandTeamAnalyze(cfg)
+	}
+	args, flags, err := parseFlags(args)
+	if err != nil {
+		return err
+	}
+	if err := checkFlags(flags, "sort", "type"); err != nil {
+		return err
+	}
+	if len(args) > 0 {
+		return fmt.Errorf("Unknown team option. Use team on its own or team analyze")
+	}
+
 	if len(cfg.pokedexCaught) == 0 {
 		fmt.Printf("\nYou haven't caught any Pokemon yet! Get out there!\n\n")
 		return nil
 	}
+	flags["caught"] = "true"
+	entries, err := listEntries(cfg, flags)
+	if err != nil {
+		return err
+	}
 	fmt.Printf("\nYou have caught the following Pokemon: ")
-	for name, givenName := range cfg.pokedexCaught {
-		if givenName != name {
-			fmt.Printf("\n * %s the %s", givenName, name)
+	for _, entry := range entries {
+		name, owned := entry.name, entry.owned
+		details := ""
+		if owned.shiny {
+			details += " (shiny)"
+		}
+		if owned.heldItem != "" {
+			details += " holding " + owned.heldItem
+		}
+		if owned.nickname != name {
+			fmt.Printf("\n * #%03d %s the %s%s", entry.dexNumber, owned.nickname, name, details)
 		} else {
-			fmt.Printf("\n * %s", name)
+			fmt.Printf("\n * #%03d %s%s", entry.dexNumber, name, details)
 		}
 	}
 	fmt.Printf("\n\nThey are your team, treat them well!\n\n")
 
 	return nil
 }
+
+// randomNature picks one of the natures listed by the API for a newly caught
+// Pokemon, or the neutral defaultNature if they cannot be fetched.
+func randomNature(cfg *config) pokeapi.SpecificNatureResp {
+
+	natures, err := cfg.pokeapiClient.ListNatures()
+	if err != nil || len(natures.Results) == 0 {
+		return pokeapi.SpecificNatureResp{Name: defaultNature}
+	}
+	nature, err := cfg.pokeapiClient.GetNature(natures.Results[rand.Intn(len(natures.Results))].Name)
+	if err != nil {
+		return pokeapi.SpecificNatureResp{Name: defaultNature}
+	}
+	return nature
+}
+
+// findOwned returns the caught Pokemon with the given species name or nickname, or nil.
+func findOwned(cfg *config, name string) *ownedPokemon {
+
+	if owned, caught := cfg.pokedexCaught[name]; caught {
+		return owned
+	}
+	return findByNickname(cfg, name)
+}
+
+// findByNickname returns the caught Pokemon with the given nickname, or nil.
+func findByNickname(cfg *config, nickname string) *ownedPokemon {
+
+	for _, owned := range cfg.pokedexCaught {
+		if strings.EqualFold(owned.nickname, nickname) {
+			return owned
+		}
+	}
+	return nil
+}
+
+// printSprite draws the Pokemon's front sprite. Sprites are decoration, so any
+// problem fetching or decoding one is ignored rather than failing the command.
+func printSprite(cfg *config, info pokeapi.SpecificPokemonResp, shiny bool) {
+
+	if !cfg.showSprites {
+		return
+	}
+	spriteURL := info.Sprites.FrontDefault
+	if shiny && info.Sprites.FrontShiny != "" {
+		spriteURL = info.Sprites.FrontShiny
+	}
+	data, err := cfg.pokeapiClient.GetSprite(spriteURL)
+	if err != nil {
+		return
+	}
+	output, err := sprite.RenderPNG(data, spriteWidth, cfg.spriteColorMode)
+	if err != nil {
+		return
+	}
+	fmt.Printf("\n%s", output)
+}
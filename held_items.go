@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/aspiringVegetarian/PokedexCLI/internal/pokeapi"
+)
+
+// rollHeldItem picks the item a wild Pokemon is holding, if any. Each item's
+// rarity is the percent chance of it being held, so a single roll against the
+// running total decides the item.
+func rollHeldItem(info pokeapi.SpecificPokemonResp) string {
+
+	roll := rand.Intn(100)
+	total := 0
+	for _, item := range heldItemRarities(info) {
+		total += item.rarity
+		if roll < total {
+			return item.name
+		}
+	}
+	return ""
+}
+
+type heldItemRarity struct {
+	name   string
+	rarity int
+}
+
+// heldItemRarities returns the rarity of each held item in the newest version
+// that lists any of them. Rarities from different versions are never mixed,
+// since together they could add up to more than 100 percent. Items missing
+// from that version are left out.
+func heldItemRarities(info pokeapi.SpecificPokemonResp) []heldItemRarity {
+
+	latestID := 0
+	for _, content := range info.HeldItems {
+		for _, details := range content.VersionDetails {
+			if id, err := pokeapi.ResourceID(details.Version.URL); err == nil {
+				latestID = max(latestID, id)
+			}
+		}
+	}
+
+	rarities := []heldItemRarity{}
+	for _, content := range info.HeldItems {
+		for _, details := range content.VersionDetails {
+			if id, err := pokeapi.ResourceID(details.Version.URL); err == nil && id == latestID {
+				rarities = append(rarities, heldItemRarity{name: content.Item.Name, rarity: details.Rarity})
+			}
+		}
+	}
+	return rarities
+}
+
+func commandBag(cfg *config, args ...string) error {
+
+	if len(cfg.inventory) == 0 {
+		fmt.Printf("\nYour bag is empty.\n\n")
+		return nil
+	}
+
+	names := []string{}
+	for name := range cfg.inventory {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Printf("\nYour bag contains: ")
+	for _, name := range names {
+		fmt.Printf("\n * %s x%v", name, cfg.inventory[name])
+	}
+	fmt.Printf("\n\n")
+
+	return nil
+}
+
+func commandGive(cfg *config, args ...string) error {
+
+	if len(args) != 2 {
+		return fmt.Errorf("Please enter a Pokemon from your team and an item from your bag after the give command")
+	}
+
+	owned := findOwned(cfg, args[0])
+	if owned == nil {
+		return fmt.Errorf("%s is not on your team", args[0])
+	}
+	if cfg.inventory[args[1]] == 0 {
+		return fmt.Errorf("You don't have a %s in your bag", args[1])
+	}
+
+	if owned.heldItem != "" {
+		cfg.inventory[owned.heldItem]++
+		fmt.Printf("\nYou took the %s from %s and put it in your bag.", owned.heldItem, owned.nickname)
+	}
+	removeFromBag(cfg, args[1])
+	owned.heldItem = args[1]
+	fmt.Printf("\n%s is now holding the %s.\n\n", owned.nickname, owned.heldItem)
+
+	return nil
+}
+
+func commandTake(cfg *config, args ...string) error {
+
+	if len(args) != 1 {
+		return fmt.Errorf("Please enter one Pokemon from your team after the take command")
+	}
+
+	owned := findOwned(cfg, args[0])
+	if owned == nil {
+		return fmt.Errorf("%s is not on your team", args[0])
+	}
+	if owned.heldItem == "" {
+		return fmt.Errorf("%s isn't holding anything", owned.nickname)
+	}
+
+	cfg.inventory[owned.heldItem]++
+	fmt.Printf("\nYou took the %s from %s and put it in your bag.\n\n", owned.heldItem, owned.nickname)
+	owned.heldItem = ""
+
+	return nil
+}
+
+func removeFromBag(cfg *config, item string) {
+
+	cfg.inventory[item]--
+	if cfg.inventory[item] <= 0 {
+		delete(cfg.inventory, item)
+	}
+}
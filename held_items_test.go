@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/aspiringVegetarian/PokedexCLI/internal/pokeapi"
+)
+
+func TestRollHeldItem(t *testing.T) {
+	info := pokeapi.SpecificPokemonResp{}
+	err := json.Unmarshal([]byte(`{"held_items": [
+		{"item": {"name": "oran-berry"}, "version_details": [
+			{"rarity": 0, "version": {"url": "https://pokeapi.co/api/v2/version/1/"}},
+			{"rarity": 100, "version": {"url": "https://pokeapi.co/api/v2/version/30/"}}
+		]}
+	]}`), &info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if item := rollHeldItem(info); item != "oran-berry" {
+		t.Errorf("expected the newest version's rarity to be used: %q", item)
+	}
+
+	if item := rollHeldItem(pokeapi.SpecificPokemonResp{}); item != "" {
+		t.Errorf("expected no held item: %q", item)
+	}
+}
+
+func TestHeldItemRarities(t *testing.T) {
+	cases := []struct {
+		name     string
+		json     string
+		expected []heldItemRarity
+	}{
+		{
+			name: "same version",
+			json: `{"held_items": [
+				{"item": {"name": "oran-berry"}, "version_details": [
+					{"rarity": 50, "version": {"url": "https://pokeapi.co/api/v2/version/30/"}}
+				]},
+				{"item": {"name": "sitrus-berry"}, "version_details": [
+					{"rarity": 5, "version": {"url": "https://pokeapi.co/api/v2/version/30/"}}
+				]}
+			]}`,
+			expected: []heldItemRarity{{name: "oran-berry", rarity: 50}, {name: "sitrus-berry", rarity: 5}},
+		},
+		{
+			name: "mismatched versions",
+			json: `{"held_items": [
+				{"item": {"name": "oran-berry"}, "version_details": [
+					{"rarity": 100, "version": {"url": "https://pokeapi.co/api/v2/version/10/"}}
+				]},
+				{"item": {"name": "sitrus-berry"}, "version_details": [
+					{"rarity": 100, "version": {"url": "https://pokeapi.co/api/v2/version/20/"}},
+					{"rarity": 5, "version": {"url": "https://pokeapi.co/api/v2/version/30/"}}
+				]}
+			]}`,
+			expected: []heldItemRarity{{name: "sitrus-berry", rarity: 5}},
+		},
+		{
+			name:     "no held items",
+			json:     `{}`,
+			expected: []heldItemRarity{},
+		},
+	}
+
+	for _, c := range cases {
+		info := pokeapi.SpecificPokemonResp{}
+		if err := json.Unmarshal([]byte(c.json), &info); err != nil {
+			t.Fatal(err)
+		}
+		if rarities := heldItemRarities(info); !slices.Equal(rarities, c.expected) {
+			t.Errorf("rarities for %s do not match: %v vs %v", c.name, rarities, c.expected)
+		}
+	}
+}
+
+func TestGiveAndTake(t *testing.T) {
+	cfg := newConfig(pokeapi.NewClient(time.Minute), defaultShinyRate, false, "en")
+	defer cfg.pokeapiClient.Close()
+	cfg.pokedexCaught["bulbasaur"] = &ownedPokemon{species: "bulbasaur", nickname: "bulbasaur", heldItem: "oran-berry"}
+
+	if err := commandTake(&cfg, "bulbasaur"); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.pokedexCaught["bulbasaur"].heldItem != "" || cfg.inventory["oran-berry"] != 1 {
+		t.Errorf("expected the item to move to the bag: %v", cfg.inventory)
+	}
+
+	if err := commandGive(&cfg, "bulbasaur", "oran-berry"); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.pokedexCaught["bulbasaur"].heldItem != "oran-berry" || len(cfg.inventory) != 0 {
+		t.Errorf("expected the item to move back to the Pokemon: %v", cfg.inventory)
+	}
+
+	if err := commandGive(&cfg, "bulbasaur", "oran-berry"); err == nil {
+		t.Errorf("expected giving an item not in the bag to fail")
+	}
+}
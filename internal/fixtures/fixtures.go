@@ -0,0 +1,25 @@
+// Package fixtures builds PokeAPI responses for tests and benchmarks.
+package fixtures
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// PokemonJSON imitates a /pokemon response with the given number of moves,
+// which make up the bulk of a real one: a long, repetitive list.
+func PokemonJSON(moves int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`{"name":"bulbasaur","id":1,"moves":[`)
+	for i := 0; i < moves; i++ {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		fmt.Fprintf(&buf, `{"move":{"name":"move-%d","url":"https://pokeapi.co/api/v2/move/%d/"},`+
+			`"version_group_details":[{"level_learned_at":%d,"move_learn_method":{"name":"level-up",`+
+			`"url":"https://pokeapi.co/api/v2/move-learn-method/1/"},"version_group":{"name":"red-blue",`+
+			`"url":"https://pokeapi.co/api/v2/version-group/1/"}}]}`, i, i, i%100)
+	}
+	buf.WriteString(`]}`)
+	return buf.Bytes()
+}
@@ -0,0 +1,24 @@
+package pokeapi
+
+import (
+	"encoding/json"
+)
+
+func (cl *Client) GetAbility(specificAbility string) (SpecificAbilityResp, error) {
+
+	fullURL := baseURL + "/ability/" + specificAbility
+
+	data, err := cl.get(fullURL)
+	if err != nil {
+		return SpecificAbilityResp{}, err
+	}
+
+	specificAbilityResp := SpecificAbilityResp{}
+
+	err = json.Unmarshal(data, &specificAbilityResp)
+	if err != nil {
+		return SpecificAbilityResp{}, err
+	}
+
+	return specificAbilityResp, nil
+}
@@ -0,0 +1,64 @@
+package pokeapi
+
+import (
+	"encoding/json"
+)
+
+func (cl *Client) ListBerries(pageURL *string) (BerryResp, error) {
+	fullURL := baseURL + "/berry?offset=0&limit=20"
+	if pageURL != nil {
+		fullURL = *pageURL
+	}
+
+	data, err := cl.get(fullURL)
+	if err != nil {
+		return BerryResp{}, err
+	}
+
+	berryResp := BerryResp{}
+
+	err = json.Unmarshal(data, &berryResp)
+	if err != nil {
+		return BerryResp{}, err
+	}
+
+	return berryResp, nil
+}
+
+func (cl *Client) GetBerry(specificBerry string) (SpecificBerryResp, error) {
+
+	fullURL := baseURL + "/berry/" + specificBerry
+
+	data, err := cl.get(fullURL)
+	if err != nil {
+		return SpecificBerryResp{}, err
+	}
+
+	specificBerryResp := SpecificBerryResp{}
+
+	err = json.Unmarshal(data, &specificBerryResp)
+	if err != nil {
+		return SpecificBerryResp{}, err
+	}
+
+	return specificBerryResp, nil
+}
+
+func (cl *Client) GetBerryFlavor(specificBerryFlavor string) (SpecificBerryFlavorResp, error) {
+
+	fullURL := baseURL + "/berry-flavor/" + specificBerryFlavor
+
+	data, err := cl.get(fullURL)
+	if err != nil {
+		return SpecificBerryFlavorResp{}, err
+	}
+
+	specificBerryFlavorResp := SpecificBerryFlavorResp{}
+
+	err = json.Unmarshal(data, &specificBerryFlavorResp)
+	if err != nil {
+		return SpecificBerryFlavorResp{}, err
+	}
+
+	return specificBerryFlavorResp, nil
+}
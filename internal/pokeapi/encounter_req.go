@@ -0,0 +1,26 @@
+package pokeapi
+
+import (
+	"encoding/json"
+)
+
+// ListPokemonEncounters returns every location area the Pokemon can be
+// encountered in. This is the resource linked by location_area_encounters.
+func (cl *Client) ListPokemonEncounters(specificPokemon string) (PokemonEncountersResp, error) {
+
+	fullURL := baseURL + "/pokemon/" + specificPokemon + "/encounters"
+
+	data, err := cl.get(fullURL)
+	if err != nil {
+		return PokemonEncountersResp{}, err
+	}
+
+	pokemonEncountersResp := PokemonEncountersResp{}
+
+	err = json.Unmarshal(data, &pokemonEncountersResp)
+	if err != nil {
+		return PokemonEncountersResp{}, err
+	}
+
+	return pokemonEncountersResp, nil
+}
@@ -0,0 +1,54 @@
+package pokeapi
+
+import (
+	"errors"
+	"sync"
+)
+
+var errFlightPanicked = errors.New("the shared request panicked")
+
+// flightGroup deduplicates concurrent requests for the same URL: while one
+// request is in flight, later callers wait for it and share its result.
+type flightGroup struct {
+	mux   sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done chan struct{}
+	data []byte
+	err  error
+}
+
+func newFlightGroup() *flightGroup {
+	return &flightGroup{
+		calls: make(map[string]*flightCall),
+	}
+}
+
+// do runs fn for key unless a call for key is already running, in which case
+// it waits for that call and returns its result instead.
+func (g *flightGroup) do(key string, fn func() ([]byte, error)) ([]byte, error) {
+
+	g.mux.Lock()
+	if call, inFlight := g.calls[key]; inFlight {
+		g.mux.Unlock()
+		<-call.done
+		return call.data, call.err
+	}
+	call := &flightCall{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mux.Unlock()
+
+	// release the waiters even if fn panics; they then see errFlightPanicked
+	call.err = errFlightPanicked
+	defer func() {
+		g.mux.Lock()
+		delete(g.calls, key)
+		g.mux.Unlock()
+		close(call.done)
+	}()
+
+	call.data, call.err = fn()
+	return call.data, call.err
+}
@@ -0,0 +1,64 @@
+package pokeapi
+
+import (
+	"encoding/json"
+)
+
+func (cl *Client) ListItems(pageURL *string) (ItemResp, error) {
+	fullURL := baseURL + "/item?offset=0&limit=20"
+	if pageURL != nil {
+		fullURL = *pageURL
+	}
+
+	data, err := cl.get(fullURL)
+	if err != nil {
+		return ItemResp{}, err
+	}
+
+	itemResp := ItemResp{}
+
+	err = json.Unmarshal(data, &itemResp)
+	if err != nil {
+		return ItemResp{}, err
+	}
+
+	return itemResp, nil
+}
+
+func (cl *Client) GetItem(specificItem string) (SpecificItemResp, error) {
+
+	fullURL := baseURL + "/item/" + specificItem
+
+	data, err := cl.get(fullURL)
+	if err != nil {
+		return SpecificItemResp{}, err
+	}
+
+	specificItemResp := SpecificItemResp{}
+
+	err = json.Unmarshal(data, &specificItemResp)
+	if err != nil {
+		return SpecificItemResp{}, err
+	}
+
+	return specificItemResp, nil
+}
+
+func (cl *Client) GetItemCategory(specificItemCategory string) (SpecificItemCategoryResp, error) {
+
+	fullURL := baseURL + "/item-category/" + specificItemCategory
+
+	data, err := cl.get(fullURL)
+	if err != nil {
+		return SpecificItemCategoryResp{}, err
+	}
+
+	specificItemCategoryResp := SpecificItemCategoryResp{}
+
+	err = json.Unmarshal(data, &specificItemCategoryResp)
+	if err != nil {
+		return SpecificItemCategoryResp{}, err
+	}
+
+	return specificItemCategoryResp, nil
+}
@@ -0,0 +1,24 @@
+package pokeapi
+
+import (
+	"encoding/json"
+)
+
+func (cl *Client) GetMove(specificMove string) (SpecificMoveResp, error) {
+
+	fullURL := baseURL + "/move/" + specificMove
+
+	data, err := cl.get(fullURL)
+	if err != nil {
+		return SpecificMoveResp{}, err
+	}
+
+	specificMoveResp := SpecificMoveResp{}
+
+	err = json.Unmarshal(data, &specificMoveResp)
+	if err != nil {
+		return SpecificMoveResp{}, err
+	}
+
+	return specificMoveResp, nil
+}
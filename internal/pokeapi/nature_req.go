@@ -0,0 +1,43 @@
+package pokeapi
+
+import (
+	"encoding/json"
+)
+
+// ListNatures returns every nature in a single page; there are only 25 of them.
+func (cl *Client) ListNatures() (NatureResp, error) {
+	fullURL := baseURL + "/nature?offset=0&limit=100"
+
+	data, err := cl.get(fullURL)
+	if err != nil {
+		return NatureResp{}, err
+	}
+
+	natureResp := NatureResp{}
+
+	err = json.Unmarshal(data, &natureResp)
+	if err != nil {
+		return NatureResp{}, err
+	}
+
+	return natureResp, nil
+}
+
+func (cl *Client) GetNature(specificNature string) (SpecificNatureResp, error) {
+
+	fullURL := baseURL + "/nature/" + specificNature
+
+	data, err := cl.get(fullURL)
+	if err != nil {
+		return SpecificNatureResp{}, err
+	}
+
+	specificNatureResp := SpecificNatureResp{}
+
+	err = json.Unmarshal(data, &specificNatureResp)
+	if err != nil {
+		return SpecificNatureResp{}, err
+	}
+
+	return specificNatureResp, nil
+}
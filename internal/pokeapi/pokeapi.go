@@ -1,24 +1,354 @@
 package pokeapi
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/aspiringVegetarian/PokedexCLI/internal/pokecache"
 )
 
-const baseURL = "https://pokeapi.co/api/v2"
+const (
+	baseURL = "https://pokeapi.co/api/v2"
+	// cacheMaxBytes bounds the response cache; full /pokemon bodies are often
+	// several hundred kilobytes and sprites or prefetching add up quickly.
+	cacheMaxBytes = 256 << 20
+	// listTTL is short because list pages change as resources are added,
+	// while resourceTTL is long because species, moves and the like are static.
+	// Either way an expired response is served for as long again while it is
+	// refreshed in the background.
+	listTTL     = 10 * time.Minute
+	resourceTTL = 24 * time.Hour
+	// decodedPokemonEntries bounds the decoded /pokemon responses kept around,
+	// which take several times the memory of their compressed bodies.
+	decodedPokemonEntries = 256
+	// diskCacheMaxBytes bounds the responses kept on disk between sessions
+	diskCacheMaxBytes = 1 << 30
+)
 
 type Client struct {
-	cache      pokecache.Cache
+	cache *pokecache.Cache
+	// disk keeps responses between sessions, behind cache, when it is not nil
+	disk       *pokecache.DiskCache
 	httpClient http.Client
+	// refreshing holds the URLs being refreshed in the background, and closed
+	// stops new refreshes once Close has been called
+	refreshing map[string]bool
+	closed     *bool
+	refreshMux *sync.Mutex
+	refreshWG  *sync.WaitGroup
+	// ctx is canceled by Close so requests still in flight give up
+	ctx    context.Context
+	cancel context.CancelFunc
+	// flights shares one request between concurrent callers asking for a URL
+	flights *flightGroup
+	// pokemon holds decoded /pokemon responses, by URL, on top of the raw cache
+	pokemon *pokecache.TypedCache[string, SpecificPokemonResp]
 }
 
+// NewClient returns a client whose cache reaps expired responses every cacheInterval.
 func NewClient(cacheInterval time.Duration) Client {
+	return NewClientWithTransport(cacheInterval, nil)
+}
+
+// NewClientWithTransport returns a client like NewClient that sends its
+// requests through transport, or http.DefaultTransport if it is nil.
+func NewClientWithTransport(cacheInterval time.Duration, transport http.RoundTripper) Client {
+	ctx, cancel := context.WithCancel(context.Background())
 	return Client{
-		cache: pokecache.NewCache(cacheInterval),
+		cache: pokecache.NewBoundedCache(cacheInterval, cacheMaxBytes),
 		httpClient: http.Client{
-			Timeout: time.Minute,
+			Transport: transport,
+			Timeout:   time.Minute,
 		},
+		refreshing: make(map[string]bool),
+		closed:     new(bool),
+		refreshMux: &sync.Mutex{},
+		refreshWG:  &sync.WaitGroup{},
+		ctx:        ctx,
+		cancel:     cancel,
+		flights:    newFlightGroup(),
+		pokemon:    pokecache.NewTypedCache[string, SpecificPokemonResp](decodedPokemonEntries, resourceTTL),
+	}
+}
+
+// UseDiskCache keeps responses in dir as well as in memory, so later sessions
+// start with them. It must be called before the client makes any requests.
+func (cl *Client) UseDiskCache(dir string) error {
+
+	disk, err := pokecache.NewDiskCache(dir, diskCacheMaxBytes)
+	if err != nil {
+		return err
+	}
+	cl.disk = disk
+	return nil
+}
+
+// get returns the raw body for fullURL, serving it from the cache when possible
+// and adding it to the cache after a successful request otherwise. Stale cached
+// responses are returned straight away and refreshed in the background.
+func (cl *Client) get(fullURL string) ([]byte, error) {
+
+	data, _, err := cl.getWithExpiry(fullURL)
+	return data, err
+}
+
+// getWithExpiry is like get and also reports when the returned body expires,
+// and whether it already has and is being refreshed.
+func (cl *Client) getWithExpiry(fullURL string) ([]byte, pokecache.Expiry, error) {
+
+	// check the cache
+
+	if data, expiry, exists := cl.cache.GetWithExpiry(fullURL); exists {
+		if expiry.Stale {
+			cl.refreshInBackground(fullURL)
+		}
+		return data, expiry, nil
+	}
+	if data, expiry, exists := cl.loadFromDisk(fullURL); exists {
+		if expiry.Stale {
+			cl.refreshInBackground(fullURL)
+		}
+		return data, expiry, nil
+	}
+
+	data, err := cl.fetchAndCache(fullURL)
+	if err != nil {
+		if data, expiry, exists := cl.loadExpiredFromDisk(fullURL); exists {
+			return data, expiry, nil
+		}
+		return nil, pokecache.Expiry{}, err
+	}
+	expiry, exists := cl.cache.Expiry(fullURL)
+	if !exists {
+		// too large to cache, or already evicted
+		expiry = pokecache.Expiry{Stale: true}
+	}
+	return data, expiry, nil
+}
+
+// fetchAndCache requests fullURL and caches the response. Concurrent calls for
+// the same URL share a single request and a single cache write. When an expired
+// response is still cached it is revalidated, so an unchanged resource costs a
+// 304 Not Modified rather than the full body.
+func (cl *Client) fetchAndCache(fullURL string) ([]byte, error) {
+
+	return cl.flights.do(fullURL, func() ([]byte, error) {
+		// a flight that just finished may have cached a fresh response
+		if expiry, exists := cl.cache.Expiry(fullURL); exists && !expiry.Stale {
+			if data, _, exists := cl.cache.GetWithExpiry(fullURL); exists {
+				return data, nil
+			}
+		}
+
+		ttl := ttlFor(fullURL)
+		validators, _ := cl.cache.Validators(fullURL)
+
+		data, newValidators, notModified, err := cl.fetch(fullURL, validators)
+		if err != nil {
+			return nil, err
+		}
+		if notModified {
+			if data, exists := cl.cache.Revalidate(fullURL, ttl, ttl); exists {
+				cl.saveToDisk(fullURL, data, ttl, validators)
+				return data, nil
+			}
+			// the cached body went away while the request was in flight
+			data, newValidators, _, err = cl.fetch(fullURL, pokecache.Validators{})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// add to cache, dropping anything decoded from the old body
+		cl.cache.AddWithValidators(fullURL, data, ttl, ttl, newValidators)
+		cl.pokemon.Remove(fullURL)
+		cl.saveToDisk(fullURL, data, ttl, newValidators)
+
+		return data, nil
+	})
+}
+
+// loadFromDisk returns the response for fullURL kept by an earlier session, and
+// puts it back in the memory cache, unless it is past its stale window.
+func (cl *Client) loadFromDisk(fullURL string) ([]byte, pokecache.Expiry, bool) {
+
+	if cl.disk == nil {
+		return nil, pokecache.Expiry{}, false
+	}
+	data, expiry, validators, exists := cl.disk.Get(fullURL)
+	if !exists || !time.Now().Before(expiry.StaleUntil) {
+		return nil, pokecache.Expiry{}, false
+	}
+	cl.cache.AddWithValidators(fullURL, data, time.Until(expiry.ExpiresAt), expiry.StaleUntil.Sub(expiry.ExpiresAt), validators)
+	return data, expiry, true
+}
+
+// loadExpiredFromDisk returns the response for fullURL kept by an earlier
+// session even if it is past its stale window, for when the network is down.
+// It goes back in the memory cache as stale, so later lookups are served
+// straight away while refreshes keep trying in the background.
+func (cl *Client) loadExpiredFromDisk(fullURL string) ([]byte, pokecache.Expiry, bool) {
+
+	if cl.disk == nil {
+		return nil, pokecache.Expiry{}, false
+	}
+	data, _, validators, exists := cl.disk.Get(fullURL)
+	if !exists {
+		return nil, pokecache.Expiry{}, false
+	}
+	cl.cache.AddWithValidators(fullURL, data, 0, ttlFor(fullURL), validators)
+	expiry, exists := cl.cache.Expiry(fullURL)
+	if !exists {
+		expiry = pokecache.Expiry{Stale: true}
+	}
+	return data, expiry, true
+}
+
+// saveToDisk keeps a response for later sessions. The disk cache is only an
+// optimization, so failing to write it is not an error.
+func (cl *Client) saveToDisk(fullURL string, data []byte, ttl time.Duration, validators pokecache.Validators) {
+
+	if cl.disk == nil {
+		return
 	}
+	cl.disk.Add(fullURL, data, ttl, ttl, validators)
+}
+
+// refreshInBackground fetches fullURL again without making the caller wait,
+// unless a refresh of it is already running or the client is closed. Failures
+// keep the stale response.
+func (cl *Client) refreshInBackground(fullURL string) {
+
+	cl.refreshMux.Lock()
+	defer cl.refreshMux.Unlock()
+	if *cl.closed || cl.refreshing[fullURL] {
+		return
+	}
+	cl.refreshing[fullURL] = true
+	cl.refreshWG.Add(1)
+
+	go func() {
+		defer cl.refreshWG.Done()
+		defer func() {
+			cl.refreshMux.Lock()
+			delete(cl.refreshing, fullURL)
+			cl.refreshMux.Unlock()
+		}()
+
+		cl.fetchAndCache(fullURL)
+	}()
+}
+
+// fetch requests fullURL from the network. Any validators are sent as
+// conditional request headers, and notModified reports a 304 response, which
+// has no body.
+func (cl *Client) fetch(fullURL string, validators pokecache.Validators) (data []byte, newValidators pokecache.Validators, notModified bool, err error) {
+
+	req, err := http.NewRequestWithContext(cl.ctx, "GET", fullURL, nil)
+	if err != nil {
+		return nil, pokecache.Validators{}, false, err
+	}
+	if validators.ETag != "" {
+		req.Header.Set("If-None-Match", validators.ETag)
+	}
+	if validators.LastModified != "" {
+		req.Header.Set("If-Modified-Since", validators.LastModified)
+	}
+
+	resp, err := cl.httpClient.Do(req)
+	if err != nil {
+		return nil, pokecache.Validators{}, false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, validators, true, nil
+	}
+	if resp.StatusCode > 399 {
+		return nil, pokecache.Validators{}, false, fmt.Errorf("bad status code: %v", resp.StatusCode)
+	}
+
+	newValidators = pokecache.Validators{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+	data, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, pokecache.Validators{}, false, err
+	}
+	return data, newValidators, false, nil
+}
+
+// ttlFor picks how long a response stays fresh. List pages are the only
+// requests with a query string.
+func ttlFor(fullURL string) time.Duration {
+
+	if strings.Contains(fullURL, "?") {
+		return listTTL
+	}
+	return resourceTTL
+}
+
+// Close cancels requests still in flight, including background refreshes, and
+// stops the background work of the client's cache. The client cannot make
+// requests afterwards.
+func (cl *Client) Close() {
+	cl.cancel()
+	cl.refreshMux.Lock()
+	*cl.closed = true
+	cl.refreshMux.Unlock()
+	cl.refreshWG.Wait()
+	cl.cache.Close()
+}
+
+// CacheStats reports how well the response cache is doing.
+func (cl *Client) CacheStats() pokecache.Stats {
+	return cl.cache.Stats()
+}
+
+// CacheEntries lists the cached responses, from most to least recently used.
+func (cl *Client) CacheEntries() []pokecache.EntryInfo {
+	return cl.cache.Entries()
+}
+
+// DiskCacheStats reports how well the disk cache is doing, and false if the
+// client does not have one.
+func (cl *Client) DiskCacheStats() (pokecache.DiskStats, bool) {
+	if cl.disk == nil {
+		return pokecache.DiskStats{}, false
+	}
+	return cl.disk.Stats(), true
+}
+
+// ClearCache drops every cached response, on disk as well as in memory.
+func (cl *Client) ClearCache() error {
+	cl.cache.Clear()
+	cl.pokemon.Clear()
+	if cl.disk == nil {
+		return nil
+	}
+	return cl.disk.Clear()
+}
+
+// PurgeCache drops the cached response for fullURL, on disk as well as in
+// memory, and reports whether there was one.
+func (cl *Client) PurgeCache(fullURL string) bool {
+	cl.pokemon.Remove(fullURL)
+	inMemory := cl.cache.Remove(fullURL)
+	onDisk := cl.disk != nil && cl.disk.Remove(fullURL)
+	return inMemory || onDisk
+}
+
+// ResourceID extracts the numeric id from a resource URL such as
+// https://pokeapi.co/api/v2/version-group/25/.
+func ResourceID(resourceURL string) (int, error) {
+
+	segments := strings.Split(strings.TrimSuffix(resourceURL, "/"), "/")
+	return strconv.Atoi(segments[len(segments)-1])
 }
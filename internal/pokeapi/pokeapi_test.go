@@ -0,0 +1,468 @@
+package pokeapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aspiringVegetarian/PokedexCLI/internal/fixtures"
+	"github.com/aspiringVegetarian/PokedexCLI/internal/pokecache"
+)
+
+const interval = 5 * time.Second
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// newTestServer serves the number of requests it has received so far.
+func newTestServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count := requests.Add(1)
+		fmt.Fprintf(w, "response %v", count)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestGetCachesResponses(t *testing.T) {
+	server, requests := newTestServer(t)
+	cl := NewClient(interval)
+	defer cl.Close()
+
+	for i := 0; i < 3; i++ {
+		data, err := cl.get(server.URL + "/pokemon/1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != "response 1" {
+			t.Errorf("expected the cached response: %q", data)
+		}
+	}
+	if requests.Load() != 1 {
+		t.Errorf("expected a single request, got %v", requests.Load())
+	}
+}
+
+func TestGetServesStaleWhileRefreshing(t *testing.T) {
+	server, requests := newTestServer(t)
+	cl := NewClient(interval)
+	defer cl.Close()
+
+	fullURL := server.URL + "/pokemon/1"
+	cl.cache.AddWithTTL(fullURL, []byte("stale response"), 0, time.Hour)
+
+	data, err := cl.get(fullURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "stale response" {
+		t.Errorf("expected the stale response to be served: %q", data)
+	}
+
+	cl.refreshWG.Wait()
+	data, err = cl.get(fullURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "response 1" {
+		t.Errorf("expected the refreshed response: %q", data)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("expected a single refresh request, got %v", requests.Load())
+	}
+}
+
+func TestCloseCancelsRefreshes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// hang until the client gives up
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	cl := NewClient(interval)
+	fullURL := server.URL + "/pokemon/1"
+	cl.cache.AddWithTTL(fullURL, []byte("stale response"), 0, time.Hour)
+	if _, err := cl.get(fullURL); err != nil {
+		t.Fatal(err)
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		cl.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("expected Close not to wait for the refresh to time out")
+	}
+}
+
+func TestTTLFor(t *testing.T) {
+	if ttlFor(baseURL+"/pokemon/?offset=0&limit=20") != listTTL {
+		t.Errorf("expected list pages to use the list time to live")
+	}
+	if ttlFor(baseURL+"/pokemon-species/1") != resourceTTL {
+		t.Errorf("expected resources to use the resource time to live")
+	}
+}
+
+func TestGetCoalescesConcurrentRequests(t *testing.T) {
+	const callers = 10
+
+	var requests atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release
+		fmt.Fprint(w, "shared response")
+	}))
+	defer server.Close()
+
+	cl := NewClient(interval)
+	defer cl.Close()
+
+	results := make(chan string, callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			data, err := cl.get(server.URL + "/pokemon/1")
+			if err != nil {
+				results <- err.Error()
+				return
+			}
+			results <- string(data)
+		}()
+	}
+
+	// let every caller reach the in-flight request before it completes
+	for requests.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	for i := 0; i < callers; i++ {
+		if result := <-results; result != "shared response" {
+			t.Errorf("expected the shared response: %q", result)
+		}
+	}
+	if requests.Load() != 1 {
+		t.Errorf("expected a single request, got %v", requests.Load())
+	}
+	if stats := cl.CacheStats(); stats.Entries != 1 {
+		t.Errorf("expected a single cache entry: %+v", stats)
+	}
+}
+
+func TestFetchAndCacheChecksCacheFirst(t *testing.T) {
+	server, requests := newTestServer(t)
+	cl := NewClient(interval)
+	defer cl.Close()
+
+	// a caller that missed just before another flight cached the response
+	fullURL := server.URL + "/pokemon/1"
+	cl.cache.AddWithTTL(fullURL, []byte("cached response"), time.Hour, 0)
+	data, err := cl.fetchAndCache(fullURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "cached response" || requests.Load() != 0 {
+		t.Errorf("expected the cached response without a request: %q, %v requests", data, requests.Load())
+	}
+}
+
+func TestDiskCacheOutlivesSession(t *testing.T) {
+	server, requests := newTestServer(t)
+	dir := t.TempDir()
+	fullURL := server.URL + "/pokemon/1"
+
+	cl := NewClient(interval)
+	if err := cl.UseDiskCache(dir); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.get(fullURL); err != nil {
+		t.Fatal(err)
+	}
+	cl.Close()
+
+	// the next session starts with an empty memory cache
+	cl = NewClient(interval)
+	defer cl.Close()
+	if err := cl.UseDiskCache(dir); err != nil {
+		t.Fatal(err)
+	}
+	data, expiry, err := cl.getWithExpiry(fullURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "response 1" || expiry.Stale || requests.Load() != 1 {
+		t.Errorf("expected the response from disk without a request: %q, %+v, %v requests", data, expiry, requests.Load())
+	}
+	if _, exists := cl.cache.Expiry(fullURL); !exists {
+		t.Error("expected the response from disk to be put back in memory")
+	}
+
+	if !cl.PurgeCache(fullURL) {
+		t.Fatal("expected the response to be purged")
+	}
+	if stats, _ := cl.DiskCacheStats(); stats.Entries != 0 {
+		t.Errorf("expected purge to remove the response from disk: %+v", stats)
+	}
+}
+
+func TestOfflineServesExpiredDiskResponse(t *testing.T) {
+	var requests atomic.Int32
+	cl := NewClientWithTransport(interval, roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		requests.Add(1)
+		return nil, fmt.Errorf("network is down")
+	}))
+	defer cl.Close()
+	if err := cl.UseDiskCache(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+
+	// prefetched long enough ago that it is past its stale window
+	fullURL := baseURL + "/pokemon/1/"
+	cl.disk.Add(fullURL, []byte("prefetched response"), 0, 0, pokecache.Validators{})
+
+	data, expiry, err := cl.getWithExpiry(fullURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "prefetched response" || !expiry.Stale {
+		t.Errorf("expected the expired response while offline: %q, %+v", data, expiry)
+	}
+	cl.refreshWG.Wait()
+
+	// later lookups come from memory without waiting on the network
+	before := requests.Load()
+	data, err = cl.get(fullURL)
+	if err != nil || string(data) != "prefetched response" {
+		t.Errorf("expected the response from memory: %q, %v", data, err)
+	}
+	cl.refreshWG.Wait()
+	if requests.Load() > before+1 {
+		t.Errorf("expected at most one background refresh, got %v requests", requests.Load()-before)
+	}
+
+	if _, err := cl.get(baseURL + "/pokemon/2/"); err == nil {
+		t.Error("expected an error for a response that was never fetched")
+	}
+}
+
+func TestFlightPanicReleasesWaiters(t *testing.T) {
+	group := newFlightGroup()
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	go func() {
+		defer func() { recover() }()
+		group.do("key", func() ([]byte, error) {
+			close(started)
+			<-release
+			panic("fetch failed")
+		})
+	}()
+	<-started
+
+	result := make(chan error)
+	go func() {
+		_, err := group.do("key", func() ([]byte, error) {
+			return nil, nil
+		})
+		result <- err
+	}()
+	// let the waiter join the flight before it panics
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	select {
+	case err := <-result:
+		if err != errFlightPanicked {
+			t.Errorf("expected the waiter to see the panic: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the waiter to be released")
+	}
+}
+
+func TestGetRevalidatesWithETag(t *testing.T) {
+	var full, notModified atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		full.Add(1)
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, "full body")
+	}))
+	defer server.Close()
+
+	cl := NewClient(interval)
+	defer cl.Close()
+	fullURL := server.URL + "/pokemon/1"
+
+	if _, err := cl.get(fullURL); err != nil {
+		t.Fatal(err)
+	}
+
+	// expire the response but keep it around so it can be revalidated
+	data, _ := cl.cache.Get(fullURL)
+	validators, _ := cl.cache.Validators(fullURL)
+	cl.cache.AddWithValidators(fullURL, data, 0, time.Hour, validators)
+
+	if _, err := cl.get(fullURL); err != nil {
+		t.Fatal(err)
+	}
+	cl.refreshWG.Wait()
+
+	data, expiry, ok := cl.cache.GetWithExpiry(fullURL)
+	if !ok || expiry.Stale || string(data) != "full body" {
+		t.Errorf("expected the 304 to refresh the cached body: %q %+v", data, expiry)
+	}
+	if full.Load() != 1 || notModified.Load() != 1 {
+		t.Errorf("expected one full download and one revalidation, got %v and %v", full.Load(), notModified.Load())
+	}
+}
+
+func TestExplorePokemonKeepsDecodedResponse(t *testing.T) {
+	cl := NewClient(interval)
+	defer cl.Close()
+	name := "bulbasaur"
+	fullURL := baseURL + "/pokemon/" + name
+	cl.cache.AddWithTTL(fullURL, fixtures.PokemonJSON(3), resourceTTL, resourceTTL)
+
+	first, err := cl.ExplorePokemon(&name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first.Moves) != 3 {
+		t.Errorf("expected the decoded moves: %v", len(first.Moves))
+	}
+	if _, ok := cl.pokemon.Get(fullURL); !ok {
+		t.Errorf("expected the decoded response to be cached")
+	}
+	if _, err := cl.ExplorePokemon(&name); err != nil {
+		t.Fatal(err)
+	}
+	if stats := cl.CacheStats(); stats.Hits != 2 {
+		t.Errorf("expected decoded hits to count as cache hits: %+v", stats)
+	}
+
+	// a new body replaces the decoded response too
+	cl.PurgeCache(fullURL)
+	cl.cache.AddWithTTL(fullURL, fixtures.PokemonJSON(5), resourceTTL, resourceTTL)
+	second, err := cl.ExplorePokemon(&name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(second.Moves) != 5 {
+		t.Errorf("expected the new body to be decoded: %v", len(second.Moves))
+	}
+}
+
+func TestExplorePokemonSkipsStaleResponse(t *testing.T) {
+	cl := NewClient(interval)
+	name := "bulbasaur"
+	fullURL := baseURL + "/pokemon/" + name
+	cl.cache.AddWithTTL(fullURL, fixtures.PokemonJSON(3), 0, time.Hour)
+
+	// the background refresh has nowhere to go
+	cl.Close()
+	if _, err := cl.ExplorePokemon(&name); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cl.pokemon.Get(fullURL); ok {
+		t.Errorf("expected a stale response not to be kept decoded")
+	}
+	if len(cl.refreshing) != 0 {
+		t.Errorf("expected no refresh to start after Close")
+	}
+}
+
+func TestExplorePokemonDecodedExpiresWithBody(t *testing.T) {
+	var requests atomic.Int32
+	cl := NewClientWithTransport(interval, roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		requests.Add(1)
+		body := io.NopCloser(bytes.NewReader(fixtures.PokemonJSON(5)))
+		return &http.Response{StatusCode: http.StatusOK, Body: body, Request: r}, nil
+	}))
+	defer cl.Close()
+	name := "bulbasaur"
+	fullURL := baseURL + "/pokemon/" + name
+	cl.cache.AddWithTTL(fullURL, fixtures.PokemonJSON(3), 20*time.Millisecond, time.Hour)
+
+	if _, err := cl.ExplorePokemon(&name); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cl.pokemon.Get(fullURL); !ok {
+		t.Fatal("expected the decoded response to be cached")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	if _, ok := cl.pokemon.Get(fullURL); ok {
+		t.Errorf("expected the decoded response to expire with its body")
+	}
+	stale, err := cl.ExplorePokemon(&name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cl.refreshWG.Wait()
+	fresh, err := cl.ExplorePokemon(&name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stale.Moves) != 3 || len(fresh.Moves) != 5 || requests.Load() != 1 {
+		t.Errorf("expected the stale body to be served and refreshed: %v, %v moves, %v requests", len(stale.Moves), len(fresh.Moves), requests.Load())
+	}
+}
+
+// BenchmarkExplorePokemonDecode measures repeated lookups of the same Pokemon
+// when only the raw body is cached, so every hit unmarshals it again.
+func BenchmarkExplorePokemonDecode(b *testing.B) {
+	cl := NewClient(interval)
+	defer cl.Close()
+	fullURL := baseURL + "/pokemon/bulbasaur"
+	cl.cache.AddWithTTL(fullURL, fixtures.PokemonJSON(80), resourceTTL, resourceTTL)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		data, err := cl.get(fullURL)
+		if err != nil {
+			b.Fatal(err)
+		}
+		resp := SpecificPokemonResp{}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkExplorePokemonTyped measures the same lookups served from the
+// decoded response cache.
+func BenchmarkExplorePokemonTyped(b *testing.B) {
+	cl := NewClient(interval)
+	defer cl.Close()
+	name := "bulbasaur"
+	cl.cache.AddWithTTL(baseURL+"/pokemon/"+name, fixtures.PokemonJSON(80), resourceTTL, resourceTTL)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := cl.ExplorePokemon(&name); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package pokeapi
+
+import (
+	"encoding/json"
+)
+
+// ListPokedexes returns every regional Pokedex, plus the national one, in a single page.
+func (cl *Client) ListPokedexes() (PokedexResp, error) {
+	fullURL := baseURL + "/pokedex?offset=0&limit=100"
+
+	data, err := cl.get(fullURL)
+	if err != nil {
+		return PokedexResp{}, err
+	}
+
+	pokedexResp := PokedexResp{}
+
+	err = json.Unmarshal(data, &pokedexResp)
+	if err != nil {
+		return PokedexResp{}, err
+	}
+
+	return pokedexResp, nil
+}
+
+func (cl *Client) GetPokedex(specificPokedex string) (SpecificPokedexResp, error) {
+
+	fullURL := baseURL + "/pokedex/" + specificPokedex
+
+	data, err := cl.get(fullURL)
+	if err != nil {
+		return SpecificPokedexResp{}, err
+	}
+
+	specificPokedexResp := SpecificPokedexResp{}
+
+	err = json.Unmarshal(data, &specificPokedexResp)
+	if err != nil {
+		return SpecificPokedexResp{}, err
+	}
+
+	return specificPokedexResp, nil
+}
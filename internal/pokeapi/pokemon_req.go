@@ -2,9 +2,6 @@ package pokeapi
 
 import (
 	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
 )
 
 func (cl *Client) ListPokemon(pageURL *string) (PokemonResp, error) {
@@ -13,44 +10,11 @@ func (cl *Client) ListPokemon(pageURL *string) (PokemonResp, error) {
 		fullURL = *pageURL
 	}
 
-	// check the cache
-
-	if data, exists := cl.cache.Get(fullURL); exists {
-		fmt.Println("Cache hit!")
-		pokemonResp := PokemonResp{}
-
-		err := json.Unmarshal(data, &pokemonResp)
-		if err != nil {
-			return PokemonResp{}, err
-		}
-
-		return pokemonResp, nil
-	}
-
-	req, err := http.NewRequest("GET", fullURL, nil)
-	if err != nil {
-		return PokemonResp{}, err
-	}
-
-	resp, err := cl.httpClient.Do(req)
-	if err != nil {
-		return PokemonResp{}, err
-	}
-
-	if resp.StatusCode > 399 {
-		return PokemonResp{}, fmt.Errorf("bad status code: %v", resp.StatusCode)
-	}
-
-	defer resp.Body.Close()
-
-	data, err := io.ReadAll(resp.Body)
+	data, err := cl.get(fullURL)
 	if err != nil {
 		return PokemonResp{}, err
 	}
 
-	// add to cache
-	cl.cache.Add(fullURL, data)
-
 	pokemonResp := PokemonResp{}
 
 	err = json.Unmarshal(data, &pokemonResp)
@@ -61,54 +25,36 @@ func (cl *Client) ListPokemon(pageURL *string) (PokemonResp, error) {
 	return pokemonResp, nil
 }
 
+// ExplorePokemon keeps the decoded response as well as the raw body, since
+// catching, comparing and searching look the same Pokemon up again and again.
 func (cl *Client) ExplorePokemon(specificPokemon *string) (SpecificPokemonResp, error) {
 
 	fullURL := baseURL + "/pokemon/" + *specificPokemon
 
-	// check the cache
-
-	if data, exists := cl.cache.Get(fullURL); exists {
-		fmt.Println("Cache hit!")
-		specificPokemonResp := SpecificPokemonResp{}
-
-		err := json.Unmarshal(data, &specificPokemonResp)
-		if err != nil {
-			return SpecificPokemonResp{}, err
+	// decoded hits count as hits on the body, which must still be cached
+	if specificPokemonResp, exists := cl.pokemon.Get(fullURL); exists {
+		if cl.cache.Touch(fullURL) {
+			return specificPokemonResp, nil
 		}
-
-		return specificPokemonResp, nil
+		cl.pokemon.Remove(fullURL)
 	}
 
-	req, err := http.NewRequest("GET", fullURL, nil)
+	data, expiry, err := cl.getWithExpiry(fullURL)
 	if err != nil {
 		return SpecificPokemonResp{}, err
 	}
 
-	resp, err := cl.httpClient.Do(req)
-	if err != nil {
-		return SpecificPokemonResp{}, err
-	}
-
-	if resp.StatusCode > 399 {
-		return SpecificPokemonResp{}, fmt.Errorf("bad status code: %v", resp.StatusCode)
-	}
-
-	defer resp.Body.Close()
-
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return SpecificPokemonResp{}, err
-	}
-
-	// add to cache
-	cl.cache.Add(fullURL, data)
-
 	specificPokemonResp := SpecificPokemonResp{}
 
 	err = json.Unmarshal(data, &specificPokemonResp)
 	if err != nil {
 		return SpecificPokemonResp{}, err
 	}
+	// the decoded copy must not outlive the body, so hits past its expiry go
+	// through get and refresh it
+	if !expiry.Stale {
+		cl.pokemon.AddWithExpiry(fullURL, specificPokemonResp, expiry.ExpiresAt)
+	}
 
 	return specificPokemonResp, nil
 }
@@ -0,0 +1,62 @@
+package pokeapi
+
+import (
+	"encoding/json"
+)
+
+// ListRegions returns every region in a single page; there are only a handful.
+func (cl *Client) ListRegions() (RegionResp, error) {
+	fullURL := baseURL + "/region?offset=0&limit=100"
+
+	data, err := cl.get(fullURL)
+	if err != nil {
+		return RegionResp{}, err
+	}
+
+	regionResp := RegionResp{}
+
+	err = json.Unmarshal(data, &regionResp)
+	if err != nil {
+		return RegionResp{}, err
+	}
+
+	return regionResp, nil
+}
+
+func (cl *Client) GetRegion(specificRegion string) (SpecificRegionResp, error) {
+
+	fullURL := baseURL + "/region/" + specificRegion
+
+	data, err := cl.get(fullURL)
+	if err != nil {
+		return SpecificRegionResp{}, err
+	}
+
+	specificRegionResp := SpecificRegionResp{}
+
+	err = json.Unmarshal(data, &specificRegionResp)
+	if err != nil {
+		return SpecificRegionResp{}, err
+	}
+
+	return specificRegionResp, nil
+}
+
+func (cl *Client) GetLocation(specificLocation string) (SpecificLocationResp, error) {
+
+	fullURL := baseURL + "/location/" + specificLocation
+
+	data, err := cl.get(fullURL)
+	if err != nil {
+		return SpecificLocationResp{}, err
+	}
+
+	specificLocationResp := SpecificLocationResp{}
+
+	err = json.Unmarshal(data, &specificLocationResp)
+	if err != nil {
+		return SpecificLocationResp{}, err
+	}
+
+	return specificLocationResp, nil
+}
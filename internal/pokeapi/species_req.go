@@ -0,0 +1,45 @@
+package pokeapi
+
+import (
+	"encoding/json"
+)
+
+func (cl *Client) ListPokemonSpecies(pageURL *string) (PokemonSpeciesResp, error) {
+	fullURL := baseURL + "/pokemon-species?offset=0&limit=20"
+	if pageURL != nil {
+		fullURL = *pageURL
+	}
+
+	data, err := cl.get(fullURL)
+	if err != nil {
+		return PokemonSpeciesResp{}, err
+	}
+
+	pokemonSpeciesResp := PokemonSpeciesResp{}
+
+	err = json.Unmarshal(data, &pokemonSpeciesResp)
+	if err != nil {
+		return PokemonSpeciesResp{}, err
+	}
+
+	return pokemonSpeciesResp, nil
+}
+
+func (cl *Client) GetPokemonSpecies(specificSpecies string) (SpecificPokemonSpeciesResp, error) {
+
+	fullURL := baseURL + "/pokemon-species/" + specificSpecies
+
+	data, err := cl.get(fullURL)
+	if err != nil {
+		return SpecificPokemonSpeciesResp{}, err
+	}
+
+	specificPokemonSpeciesResp := SpecificPokemonSpeciesResp{}
+
+	err = json.Unmarshal(data, &specificPokemonSpeciesResp)
+	if err != nil {
+		return SpecificPokemonSpeciesResp{}, err
+	}
+
+	return specificPokemonSpeciesResp, nil
+}
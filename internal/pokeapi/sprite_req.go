@@ -0,0 +1,16 @@
+package pokeapi
+
+import (
+	"fmt"
+)
+
+// GetSprite downloads the PNG at spriteURL. Sprites are served from GitHub
+// rather than the API itself, so the full URL is taken as given.
+func (cl *Client) GetSprite(spriteURL string) ([]byte, error) {
+
+	if spriteURL == "" {
+		return nil, fmt.Errorf("no sprite available")
+	}
+
+	return cl.get(spriteURL)
+}
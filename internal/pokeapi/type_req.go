@@ -0,0 +1,44 @@
+package pokeapi
+
+import (
+	"encoding/json"
+)
+
+// ListTypes returns every type in a single page, including the unused
+// "unknown" and "shadow" types.
+func (cl *Client) ListTypes() (TypeResp, error) {
+	fullURL := baseURL + "/type?offset=0&limit=100"
+
+	data, err := cl.get(fullURL)
+	if err != nil {
+		return TypeResp{}, err
+	}
+
+	typeResp := TypeResp{}
+
+	err = json.Unmarshal(data, &typeResp)
+	if err != nil {
+		return TypeResp{}, err
+	}
+
+	return typeResp, nil
+}
+
+func (cl *Client) GetType(specificType string) (SpecificTypeResp, error) {
+
+	fullURL := baseURL + "/type/" + specificType
+
+	data, err := cl.get(fullURL)
+	if err != nil {
+		return SpecificTypeResp{}, err
+	}
+
+	specificTypeResp := SpecificTypeResp{}
+
+	err = json.Unmarshal(data, &specificTypeResp)
+	if err != nil {
+		return SpecificTypeResp{}, err
+	}
+
+	return specificTypeResp, nil
+}
@@ -0,0 +1,64 @@
+package pokeapi
+
+type BerryResp struct {
+	Count    *int    `json:"count"`
+	Next     *string `json:"next"`
+	Previous *string `json:"previous"`
+	Results  []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"results"`
+}
+
+type SpecificBerryResp struct {
+	Firmness struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"firmness"`
+	Flavors []struct {
+		Flavor struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"flavor"`
+		Potency int `json:"potency"`
+	} `json:"flavors"`
+	GrowthTime int `json:"growth_time"`
+	ID         int `json:"id"`
+	Item       struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"item"`
+	MaxHarvest       int    `json:"max_harvest"`
+	Name             string `json:"name"`
+	NaturalGiftPower int    `json:"natural_gift_power"`
+	NaturalGiftType  struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"natural_gift_type"`
+	Size        int `json:"size"`
+	Smoothness  int `json:"smoothness"`
+	SoilDryness int `json:"soil_dryness"`
+}
+
+type SpecificBerryFlavorResp struct {
+	Berries []struct {
+		Berry struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"berry"`
+		Potency int `json:"potency"`
+	} `json:"berries"`
+	ContestType struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"contest_type"`
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Names []struct {
+		Language struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"language"`
+		Name string `json:"name"`
+	} `json:"names"`
+}
@@ -0,0 +1,28 @@
+package pokeapi
+
+type PokemonEncountersResp []struct {
+	LocationArea struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"location_area"`
+	VersionDetails []struct {
+		EncounterDetails []struct {
+			Chance          int `json:"chance"`
+			ConditionValues []struct {
+				Name string `json:"name"`
+				URL  string `json:"url"`
+			} `json:"condition_values"`
+			MaxLevel int `json:"max_level"`
+			Method   struct {
+				Name string `json:"name"`
+				URL  string `json:"url"`
+			} `json:"method"`
+			MinLevel int `json:"min_level"`
+		} `json:"encounter_details"`
+		MaxChance int `json:"max_chance"`
+		Version   struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"version"`
+	} `json:"version_details"`
+}
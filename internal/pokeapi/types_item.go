@@ -0,0 +1,79 @@
+package pokeapi
+
+type ItemResp struct {
+	Count    *int    `json:"count"`
+	Next     *string `json:"next"`
+	Previous *string `json:"previous"`
+	Results  []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"results"`
+}
+
+type SpecificItemResp struct {
+	Attributes []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"attributes"`
+	Category struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"category"`
+	Cost          int `json:"cost"`
+	EffectEntries []struct {
+		Effect   string `json:"effect"`
+		Language struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"language"`
+		ShortEffect string `json:"short_effect"`
+	} `json:"effect_entries"`
+	FlavorTextEntries []struct {
+		Language struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"language"`
+		Text         string `json:"text"`
+		VersionGroup struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"version_group"`
+	} `json:"flavor_text_entries"`
+	FlingEffect *struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"fling_effect"`
+	FlingPower *int   `json:"fling_power"`
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	Names      []struct {
+		Language struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"language"`
+		Name string `json:"name"`
+	} `json:"names"`
+	Sprites struct {
+		Default string `json:"default"`
+	} `json:"sprites"`
+}
+
+type SpecificItemCategoryResp struct {
+	ID    int `json:"id"`
+	Items []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"items"`
+	Name  string `json:"name"`
+	Names []struct {
+		Language struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"language"`
+		Name string `json:"name"`
+	} `json:"names"`
+	Pocket struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"pocket"`
+}
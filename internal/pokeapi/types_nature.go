@@ -0,0 +1,39 @@
+package pokeapi
+
+type NatureResp struct {
+	Count    *int    `json:"count"`
+	Next     *string `json:"next"`
+	Previous *string `json:"previous"`
+	Results  []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"results"`
+}
+
+type SpecificNatureResp struct {
+	ID            int `json:"id"`
+	DecreasedStat *struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"decreased_stat"`
+	HatesFlavor *struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"hates_flavor"`
+	IncreasedStat *struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"increased_stat"`
+	LikesFlavor *struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"likes_flavor"`
+	Name  string `json:"name"`
+	Names []struct {
+		Language struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"language"`
+		Name string `json:"name"`
+	} `json:"names"`
+}
@@ -0,0 +1,46 @@
+package pokeapi
+
+type PokedexResp struct {
+	Count    *int    `json:"count"`
+	Next     *string `json:"next"`
+	Previous *string `json:"previous"`
+	Results  []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"results"`
+}
+
+type SpecificPokedexResp struct {
+	Descriptions []struct {
+		Description string `json:"description"`
+		Language    struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"language"`
+	} `json:"descriptions"`
+	ID           int    `json:"id"`
+	IsMainSeries bool   `json:"is_main_series"`
+	Name         string `json:"name"`
+	Names        []struct {
+		Language struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"language"`
+		Name string `json:"name"`
+	} `json:"names"`
+	PokemonEntries []struct {
+		EntryNumber    int `json:"entry_number"`
+		PokemonSpecies struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"pokemon_species"`
+	} `json:"pokemon_entries"`
+	Region *struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"region"`
+	VersionGroups []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"version_groups"`
+}
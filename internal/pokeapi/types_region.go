@@ -0,0 +1,66 @@
+package pokeapi
+
+type RegionResp struct {
+	Count    *int    `json:"count"`
+	Next     *string `json:"next"`
+	Previous *string `json:"previous"`
+	Results  []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"results"`
+}
+
+type SpecificRegionResp struct {
+	ID        int `json:"id"`
+	Locations []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"locations"`
+	MainGeneration struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"main_generation"`
+	Name  string `json:"name"`
+	Names []struct {
+		Language struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"language"`
+		Name string `json:"name"`
+	} `json:"names"`
+	Pokedexes []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"pokedexes"`
+	VersionGroups []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"version_groups"`
+}
+
+type SpecificLocationResp struct {
+	Areas []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"areas"`
+	GameIndices []struct {
+		GameIndex  int `json:"game_index"`
+		Generation struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"generation"`
+	} `json:"game_indices"`
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Names []struct {
+		Language struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"language"`
+		Name string `json:"name"`
+	} `json:"names"`
+	Region *struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"region"`
+}
@@ -0,0 +1,50 @@
+package pokecache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+)
+
+const (
+	// defaultCompressThreshold is the smallest value worth compressing. Full
+	// /pokemon responses are hundreds of kilobytes of repetitive JSON, while
+	// list pages and small resources are not worth the CPU time.
+	defaultCompressThreshold = 8 << 10
+	// maxCompressedRatio is how small the compressed value must be, relative to
+	// the original, to be kept. Already compressed data such as PNG sprites
+	// barely shrinks and is stored as is.
+	maxCompressedRatio = 0.9
+)
+
+// compress gzips val if it is at least threshold bytes and shrinks enough to
+// be worth it. It reports whether the returned value is compressed.
+func compress(val []byte, threshold int) ([]byte, bool) {
+
+	if threshold <= 0 || len(val) < threshold {
+		return val, false
+	}
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(val); err != nil {
+		return val, false
+	}
+	if err := writer.Close(); err != nil {
+		return val, false
+	}
+	if float64(buf.Len()) > float64(len(val))*maxCompressedRatio {
+		return val, false
+	}
+	return buf.Bytes(), true
+}
+
+func decompress(val []byte) ([]byte, error) {
+
+	reader, err := gzip.NewReader(bytes.NewReader(val))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
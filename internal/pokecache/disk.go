@@ -0,0 +1,278 @@
+package pokecache
+
+import (
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// diskFileExt marks the files a DiskCache owns, so Clear and eviction leave
+// anything else in the directory alone.
+const diskFileExt = ".entry"
+
+// DiskCache keeps values in files so they outlive the process. Like Cache it
+// stores large values gzipped, and when it grows past maxBytes it deletes the
+// least recently used files. Entries are not dropped when they expire, since
+// an old response is still better than none when the network is down; callers
+// decide what to do with expired values.
+type DiskCache struct {
+	dir               string
+	maxBytes          int
+	compressThreshold int
+	// mux guards size and serializes writes, removals and eviction
+	mux  *sync.Mutex
+	size int
+	hits atomic.Int64
+	miss atomic.Int64
+}
+
+// diskEntry is the gob encoded content of an entry's file.
+type diskEntry struct {
+	Key        string
+	Val        []byte
+	Compressed bool
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+	StaleUntil time.Time
+	Validators Validators
+}
+
+// DiskStats describes a disk cache.
+type DiskStats struct {
+	Dir      string
+	Hits     int
+	Misses   int
+	Entries  int
+	Bytes    int
+	MaxBytes int
+}
+
+// NewDiskCache opens, creating it if needed, a cache in dir holding at most
+// maxBytes of files. A maxBytes of 0 means no limit.
+func NewDiskCache(dir string, maxBytes int) (*DiskCache, error) {
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	d := &DiskCache{
+		dir:               dir,
+		maxBytes:          maxBytes,
+		compressThreshold: defaultCompressThreshold,
+		mux:               &sync.Mutex{},
+	}
+	files, err := d.files()
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		d.size += int(file.Size())
+	}
+	return d, nil
+}
+
+// Add writes val to disk, replacing any value already stored under key. It is
+// kept fresh for ttl and stale for staleFor more, like Cache.AddWithValidators.
+func (d *DiskCache) Add(key string, val []byte, ttl, staleFor time.Duration, validators Validators) error {
+
+	stored, compressed := compress(val, d.compressThreshold)
+	now := time.Now().UTC()
+	entry := diskEntry{
+		Key:        key,
+		Val:        stored,
+		Compressed: compressed,
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(ttl),
+		StaleUntil: now.Add(ttl + staleFor),
+		Validators: validators,
+	}
+
+	// write to a temporary file first so readers never see a partial entry
+	tmp, err := os.CreateTemp(d.dir, "tmp-*")
+	if err != nil {
+		return err
+	}
+	if err := gob.NewEncoder(tmp).Encode(entry); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	info, err := os.Stat(tmp.Name())
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	path := d.path(key)
+	d.size -= fileSize(path)
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	d.size += int(info.Size())
+	d.evict()
+	return nil
+}
+
+// Get returns the value stored under key along with its expiry and validators.
+// Unlike Cache.Get it also returns values past their stale window.
+func (d *DiskCache) Get(key string) (data []byte, expiry Expiry, validators Validators, exists bool) {
+
+	path := d.path(key)
+	file, err := os.Open(path)
+	if err != nil {
+		d.miss.Add(1)
+		return nil, Expiry{}, Validators{}, false
+	}
+	defer file.Close()
+
+	entry := diskEntry{}
+	if err := gob.NewDecoder(file).Decode(&entry); err != nil || entry.Key != key {
+		d.miss.Add(1)
+		return nil, Expiry{}, Validators{}, false
+	}
+	data = entry.Val
+	if entry.Compressed {
+		if data, err = decompress(entry.Val); err != nil {
+			d.miss.Add(1)
+			return nil, Expiry{}, Validators{}, false
+		}
+	}
+
+	// the modification time doubles as the last use for eviction
+	now := time.Now()
+	os.Chtimes(path, now, now)
+	d.hits.Add(1)
+	expiry = Expiry{
+		ExpiresAt:  entry.ExpiresAt,
+		StaleUntil: entry.StaleUntil,
+		Stale:      !now.UTC().Before(entry.ExpiresAt),
+	}
+	return data, expiry, entry.Validators, true
+}
+
+// Remove deletes the value stored under key and reports whether there was one.
+func (d *DiskCache) Remove(key string) bool {
+
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	path := d.path(key)
+	size := fileSize(path)
+	if err := os.Remove(path); err != nil {
+		return false
+	}
+	d.size -= size
+	return true
+}
+
+// Clear deletes every stored value.
+func (d *DiskCache) Clear() error {
+
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	files, err := d.files()
+	if err != nil {
+		return err
+	}
+	errs := []error{}
+	for _, file := range files {
+		if err := os.Remove(filepath.Join(d.dir, file.Name())); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		d.size -= int(file.Size())
+	}
+	return errors.Join(errs...)
+}
+
+// Stats describes how much is stored and how often it was found.
+func (d *DiskCache) Stats() DiskStats {
+
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	entries := 0
+	if files, err := d.files(); err == nil {
+		entries = len(files)
+	}
+	return DiskStats{
+		Dir:      d.dir,
+		Hits:     int(d.hits.Load()),
+		Misses:   int(d.miss.Load()),
+		Entries:  entries,
+		Bytes:    d.size,
+		MaxBytes: d.maxBytes,
+	}
+}
+
+// evict deletes the least recently used files until the cache fits in
+// maxBytes. The caller must hold the lock.
+func (d *DiskCache) evict() {
+
+	if d.maxBytes <= 0 || d.size <= d.maxBytes {
+		return
+	}
+	files, err := d.files()
+	if err != nil {
+		return
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+	for _, file := range files {
+		if d.size <= d.maxBytes {
+			return
+		}
+		if os.Remove(filepath.Join(d.dir, file.Name())) == nil {
+			d.size -= int(file.Size())
+		}
+	}
+}
+
+// files lists the entry files in the cache's directory.
+func (d *DiskCache) files() ([]fs.FileInfo, error) {
+
+	dirEntries, err := os.ReadDir(d.dir)
+	if err != nil {
+		return nil, err
+	}
+	files := []fs.FileInfo{}
+	for _, dirEntry := range dirEntries {
+		if !strings.HasSuffix(dirEntry.Name(), diskFileExt) {
+			continue
+		}
+		if info, err := dirEntry.Info(); err == nil {
+			files = append(files, info)
+		}
+	}
+	return files, nil
+}
+
+// path names an entry's file after the hash of its key, since keys are URLs.
+func (d *DiskCache) path(key string) string {
+
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])+diskFileExt)
+}
+
+func fileSize(path string) int {
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return int(info.Size())
+}
@@ -1,64 +1,462 @@
 package pokecache
 
 import (
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 type cacheEntry struct {
-	val       []byte
-	createdAt time.Time
+	key string
+	val []byte
+	// compressed values are gzipped, and rawSize is their original length
+	compressed bool
+	rawSize    int
+	createdAt  time.Time
+	// expiresAt is when the entry goes stale, and staleUntil is when it is gone
+	expiresAt  time.Time
+	staleUntil time.Time
+	validators Validators
+	// listedAt orders the entry in its shard's recency heap, and lastUsed is
+	// set by reads without the write lock. Both are Cache.now ticks.
+	listedAt int64
+	lastUsed atomic.Int64
+	// index is the entry's position in its shard's recency heap
+	index int
 }
 
+// Cache is split into shards by key hash, each with its own lock and an equal
+// share of maxBytes, so concurrent callers rarely wait on each other.
 type Cache struct {
-	entries map[string]cacheEntry
-	mux     *sync.Mutex
+	shards   []*shard
+	maxBytes int
+	// compressThreshold is the smallest value that is compressed, 0 for none
+	compressThreshold int
+	// interval is the default time to live and how often the reaper runs
+	interval time.Duration
+	// clock ticks once per use, ordering entries by when they were last used
+	clock *atomic.Int64
+	// done is closed by Close to stop the reaper, which closes stopped on exit
+	done      chan struct{}
+	stopped   chan struct{}
+	closeOnce *sync.Once
 }
 
-func NewCache(interval time.Duration) Cache {
+// Stats counts how the cache has been used since it was created.
+type Stats struct {
+	Hits          int
+	StaleHits     int
+	Revalidations int
+	Misses        int
+	Evictions     int
+	Expired       int
+	Entries       int
+	Bytes         int
+	MaxBytes      int
+}
+
+// EntryInfo describes a cached value without copying it.
+type EntryInfo struct {
+	Key string
+	// Size is the number of bytes stored, RawSize the size of the value itself
+	Size       int
+	RawSize    int
+	Compressed bool
+	CreatedAt  time.Time
+	Expiry
+}
+
+// Validators are the HTTP validators a cached response came with, used to ask
+// the server whether an expired response is still current.
+type Validators struct {
+	ETag         string
+	LastModified string
+}
+
+// Expiry tells when a cached value goes stale and when it is dropped. Stale
+// values are still returned so they can be served while being refreshed.
+type Expiry struct {
+	ExpiresAt  time.Time
+	StaleUntil time.Time
+	Stale      bool
+}
+
+// NewCache returns a cache whose entries expire once they are older than
+// interval, unless they are added with their own time to live. Expired entries
+// are reaped every interval and the cache can grow without bound in between.
+// Close the cache to stop its reaper goroutine.
+func NewCache(interval time.Duration) *Cache {
+	return NewBoundedCache(interval, 0)
+}
+
+// NewBoundedCache returns a cache like NewCache that also holds at most
+// maxBytes of values, evicting the least recently used entries to make room.
+// A maxBytes of 0 means no limit. Large values are stored gzipped, and their
+// compressed size is what counts against maxBytes. The bound is split evenly
+// between the cache's shards, so a value larger than one shard's share is not
+// stored.
+func NewBoundedCache(interval time.Duration, maxBytes int) *Cache {
+	return newShardedCache(interval, maxBytes, shardCount(maxBytes))
+}
+
+func newShardedCache(interval time.Duration, maxBytes, shards int) *Cache {
 
-	newCache := Cache{
-		entries: make(map[string]cacheEntry),
-		mux:     &sync.Mutex{},
+	newCache := &Cache{
+		shards:            make([]*shard, shards),
+		maxBytes:          maxBytes,
+		interval:          interval,
+		compressThreshold: defaultCompressThreshold,
+		clock:             &atomic.Int64{},
+		done:              make(chan struct{}),
+		stopped:           make(chan struct{}),
+		closeOnce:         &sync.Once{},
+	}
+	for i := range newCache.shards {
+		newCache.shards[i] = newShard(maxBytes / shards)
 	}
 	go newCache.reapLoop(interval)
 	return newCache
 }
 
+// Add caches val for the cache's default interval.
 func (c *Cache) Add(key string, val []byte) {
+	c.AddWithTTL(key, val, c.interval, 0)
+}
+
+// AddWithTTL caches val for ttl. Afterwards it is kept for staleFor more, during
+// which Get still returns it but reports it as stale.
+func (c *Cache) AddWithTTL(key string, val []byte, ttl, staleFor time.Duration) {
+	c.AddWithValidators(key, val, ttl, staleFor, Validators{})
+}
+
+// AddWithValidators is like AddWithTTL and also keeps the validators the value
+// was served with, so it can be revalidated once it expires.
+func (c *Cache) AddWithValidators(key string, val []byte, ttl, staleFor time.Duration, validators Validators) {
 
-	c.mux.Lock()
-	defer c.mux.Unlock()
-	c.entries[key] = cacheEntry{
-		val:       val,
-		createdAt: time.Now().UTC(),
+	// compress before locking so other callers are not held up
+	stored, compressed := compress(val, c.compressThreshold)
+	now := time.Now().UTC()
+	entry := &cacheEntry{
+		key:        key,
+		val:        stored,
+		compressed: compressed,
+		rawSize:    len(val),
+		createdAt:  now,
+		expiresAt:  now.Add(ttl),
+		staleUntil: now.Add(ttl + staleFor),
+		validators: validators,
 	}
+
+	s := c.shardFor(key)
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.add(entry, c.now())
 }
 
 func (c *Cache) Get(key string) (data []byte, exists bool) {
 
-	c.mux.Lock()
-	defer c.mux.Unlock()
-	entry, exists := c.entries[key]
-	return entry.val, exists
+	data, _, exists = c.GetWithExpiry(key)
+	return data, exists
+}
+
+// GetWithExpiry is like Get but also reports when the value expires and
+// whether it already has. Values past their stale window are never returned,
+// even if the reaper has not removed them yet.
+func (c *Cache) GetWithExpiry(key string) (data []byte, expiry Expiry, exists bool) {
+
+	s := c.shardFor(key)
+	now := time.Now().UTC()
+
+	s.mux.RLock()
+	entry, exists := s.entries[key]
+	if !exists {
+		s.mux.RUnlock()
+		s.counters.misses.Add(1)
+		return nil, Expiry{}, false
+	}
+	if !now.Before(entry.staleUntil) {
+		s.mux.RUnlock()
+		c.removeExpired(s, key)
+		s.counters.misses.Add(1)
+		return nil, Expiry{}, false
+	}
+	expiry = entry.expiry(now)
+	val, compressed := entry.val, entry.compressed
+	entry.markUsed(c.now())
+	s.mux.RUnlock()
+
+	if expiry.Stale {
+		s.counters.staleHits.Add(1)
+	} else {
+		s.counters.hits.Add(1)
+	}
+	data, exists = c.value(key, val, compressed)
+	return data, expiry, exists
+}
+
+// Touch counts a hit on key and marks it as used without returning the value,
+// for callers that keep their own decoded copy of it. It reports false if key
+// is not cached or past its stale window.
+func (c *Cache) Touch(key string) bool {
+
+	s := c.shardFor(key)
+	now := time.Now().UTC()
+
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	entry, exists := s.entries[key]
+	if !exists || !now.Before(entry.staleUntil) {
+		return false
+	}
+	entry.markUsed(c.now())
+	if entry.expiry(now).Stale {
+		s.counters.staleHits.Add(1)
+	} else {
+		s.counters.hits.Add(1)
+	}
+	return true
+}
+
+// removeExpired drops key if it is still past its stale window, since a read
+// found it that way but could not remove it under the read lock.
+func (c *Cache) removeExpired(s *shard, key string) {
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if entry, exists := s.entries[key]; exists && !time.Now().UTC().Before(entry.staleUntil) {
+		s.remove(key)
+		s.counters.expired.Add(1)
+	}
+}
+
+// value returns the original bytes of a stored value, decompressing them
+// without holding a lock. A value that fails to decompress is dropped and
+// reported missing.
+func (c *Cache) value(key string, val []byte, compressed bool) ([]byte, bool) {
+
+	if !compressed {
+		return val, true
+	}
+	data, err := decompress(val)
+	if err != nil {
+		c.Remove(key)
+		return nil, false
+	}
+	return data, true
+}
+
+// markUsed records a use at tick. Concurrent readers may store their ticks out
+// of order, so a smaller tick never replaces a larger one.
+func (entry *cacheEntry) markUsed(tick int64) {
+
+	for {
+		used := entry.lastUsed.Load()
+		if used >= tick || entry.lastUsed.CompareAndSwap(used, tick) {
+			return
+		}
+	}
+}
+
+func (entry *cacheEntry) expiry(now time.Time) Expiry {
+	return Expiry{
+		ExpiresAt:  entry.expiresAt,
+		StaleUntil: entry.staleUntil,
+		Stale:      !now.Before(entry.expiresAt),
+	}
+}
+
+// Expiry returns when the value for key expires, if it is cached, without
+// counting it as used.
+func (c *Cache) Expiry(key string) (Expiry, bool) {
+
+	s := c.shardFor(key)
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	entry, exists := s.entries[key]
+	if !exists {
+		return Expiry{}, false
+	}
+	return entry.expiry(time.Now().UTC()), true
+}
+
+// Validators returns the validators stored with key, if it is cached.
+func (c *Cache) Validators(key string) (Validators, bool) {
+
+	s := c.shardFor(key)
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	entry, exists := s.entries[key]
+	if !exists {
+		return Validators{}, false
+	}
+	return entry.validators, true
+}
+
+// Revalidate marks the cached value for key as current again, for example
+// after the server answered 304 Not Modified, and returns it. It reports false
+// if key is no longer cached.
+func (c *Cache) Revalidate(key string, ttl, staleFor time.Duration) ([]byte, bool) {
+
+	s := c.shardFor(key)
+	s.mux.Lock()
+	entry, exists := s.entries[key]
+	if !exists {
+		s.mux.Unlock()
+		return nil, false
+	}
+	now := time.Now().UTC()
+	entry.createdAt = now
+	entry.expiresAt = now.Add(ttl)
+	entry.staleUntil = now.Add(ttl + staleFor)
+	entry.markUsed(c.now())
+	val, compressed := entry.val, entry.compressed
+	s.counters.revalidations.Add(1)
+	s.mux.Unlock()
+
+	return c.value(key, val, compressed)
+}
+
+// Remove deletes the entry for key and reports whether there was one.
+func (c *Cache) Remove(key string) bool {
+
+	s := c.shardFor(key)
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	_, exists := s.entries[key]
+	s.remove(key)
+	return exists
+}
+
+// Clear deletes every entry. The usage counters are kept.
+func (c *Cache) Clear() {
+
+	for _, s := range c.shards {
+		s.mux.Lock()
+		s.entries = make(map[string]*cacheEntry)
+		s.recency = s.recency[:0]
+		s.size = 0
+		s.mux.Unlock()
+	}
+}
+
+// Stats returns the usage counters along with the current size of the cache.
+func (c *Cache) Stats() Stats {
+
+	stats := Stats{MaxBytes: c.maxBytes}
+	for _, s := range c.shards {
+		stats.Hits += int(s.counters.hits.Load())
+		stats.StaleHits += int(s.counters.staleHits.Load())
+		stats.Revalidations += int(s.counters.revalidations.Load())
+		stats.Misses += int(s.counters.misses.Load())
+		stats.Evictions += int(s.counters.evictions.Load())
+		stats.Expired += int(s.counters.expired.Load())
+
+		s.mux.RLock()
+		stats.Entries += len(s.entries)
+		stats.Bytes += s.size
+		s.mux.RUnlock()
+	}
+	return stats
+}
+
+// Entries describes every cached value, from most to least recently used.
+func (c *Cache) Entries() []EntryInfo {
+
+	type usedEntry struct {
+		info   EntryInfo
+		usedAt int64
+	}
+	now := time.Now().UTC()
+	used := []usedEntry{}
+	for _, s := range c.shards {
+		s.mux.RLock()
+		for _, entry := range s.entries {
+			used = append(used, usedEntry{
+				info: EntryInfo{
+					Key:        entry.key,
+					Size:       len(entry.val),
+					RawSize:    entry.rawSize,
+					Compressed: entry.compressed,
+					CreatedAt:  entry.createdAt,
+					Expiry:     entry.expiry(now),
+				},
+				usedAt: max(entry.listedAt, entry.lastUsed.Load()),
+			})
+		}
+		s.mux.RUnlock()
+	}
+	sort.Slice(used, func(i, j int) bool {
+		return used[i].usedAt > used[j].usedAt
+	})
+
+	infos := make([]EntryInfo, 0, len(used))
+	for _, entry := range used {
+		infos = append(infos, entry.info)
+	}
+	return infos
+}
+
+// Size returns the total number of bytes held in cached values, counting
+// compressed values at their compressed size.
+func (c *Cache) Size() int {
+
+	size := 0
+	for _, s := range c.shards {
+		s.mux.RLock()
+		size += s.size
+		s.mux.RUnlock()
+	}
+	return size
+}
+
+// Close stops the reaper goroutine and waits for it to exit. The cache can
+// still be used afterwards, but entries are no longer reaped. Calling Close
+// more than once is safe.
+func (c *Cache) Close() {
+
+	c.closeOnce.Do(func() {
+		close(c.done)
+	})
+	<-c.stopped
 }
 
 func (c *Cache) reapLoop(interval time.Duration) {
 
+	defer close(c.stopped)
 	ticker := time.NewTicker(interval)
-	for range ticker.C {
-		c.reap(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.reap()
+		case <-c.done:
+			return
+		}
 	}
 }
 
-func (c *Cache) reap(interval time.Duration) {
+func (c *Cache) reap() {
 
-	c.mux.Lock()
-	defer c.mux.Unlock()
-	intervalTimeAgo := time.Now().UTC().Add(-interval)
-	for k, entry := range c.entries {
-		if entry.createdAt.Before(intervalTimeAgo) {
-			delete(c.entries, k)
+	now := time.Now().UTC()
+	for _, s := range c.shards {
+		s.mux.Lock()
+		for k, entry := range s.entries {
+			if !now.Before(entry.staleUntil) {
+				s.remove(k)
+				s.counters.expired.Add(1)
+			}
 		}
+		s.mux.Unlock()
 	}
 }
+
+func (c *Cache) shardFor(key string) *shard {
+	return c.shards[shardIndex(key, len(c.shards))]
+}
+
+// now returns the next tick of the cache's clock. Every call returns a larger
+// value, so no two uses of an entry look simultaneous.
+func (c *Cache) now() int64 {
+	return c.clock.Add(1)
+}
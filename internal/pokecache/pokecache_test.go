@@ -1,17 +1,26 @@
 package pokecache
 
 import (
+	"bytes"
 	"fmt"
+	"math/rand"
+	"os"
+	"runtime"
+	"slices"
+	"sync"
 	"testing"
 	"time"
+
+	"github.com/aspiringVegetarian/PokedexCLI/internal/fixtures"
 )
 
 const interval = 5 * time.Second
 
 func TestCreateCache(t *testing.T) {
 	cache := NewCache(interval)
-	if cache.entries == nil {
-		t.Error("cache is nil")
+	defer cache.Close()
+	if len(cache.shards) == 0 {
+		t.Error("cache has no shards")
 	}
 }
 
@@ -33,6 +42,7 @@ func TestAddGet(t *testing.T) {
 	for i, c := range cases {
 		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
 			cache := NewCache(interval)
+			defer cache.Close()
 			cache.Add(c.key, c.val)
 			val, ok := cache.Get(c.key)
 			if !ok {
@@ -51,6 +61,7 @@ func TestReapLoop(t *testing.T) {
 	const baseTime = 5 * time.Millisecond
 	const waitTime = baseTime + time.Millisecond
 	cache := NewCache(baseTime)
+	defer cache.Close()
 	cache.Add("https://example.com", []byte("testdata"))
 
 	_, ok := cache.Get("https://example.com")
@@ -72,6 +83,7 @@ func TestReapLoopFail(t *testing.T) {
 	const baseTime = 5 * time.Millisecond
 	const waitTime = time.Millisecond
 	cache := NewCache(baseTime)
+	defer cache.Close()
 	cache.Add("https://example.com", []byte("testdata"))
 
 	_, ok := cache.Get("https://example.com")
@@ -88,3 +100,642 @@ func TestReapLoopFail(t *testing.T) {
 		return
 	}
 }
+
+func TestEvictLeastRecentlyUsed(t *testing.T) {
+	cache := NewBoundedCache(interval, 12)
+	defer cache.Close()
+	cache.Add("https://example.com/a", []byte("aaaa"))
+	cache.Add("https://example.com/b", []byte("bbbb"))
+	cache.Add("https://example.com/c", []byte("cccc"))
+
+	// using a makes b the least recently used entry
+	if _, ok := cache.Get("https://example.com/a"); !ok {
+		t.Errorf("expected to find key")
+		return
+	}
+	cache.Add("https://example.com/d", []byte("dddd"))
+
+	if _, ok := cache.Get("https://example.com/b"); ok {
+		t.Errorf("expected the least recently used key to be evicted")
+	}
+
+	// use the rest so c is the least recently used, then a, then d
+	for _, key := range []string{"https://example.com/c", "https://example.com/a", "https://example.com/d"} {
+		if _, ok := cache.Get(key); !ok {
+			t.Errorf("expected to find %s", key)
+		}
+	}
+	if cache.Size() != 12 {
+		t.Errorf("expected a size of 12, got %v", cache.Size())
+	}
+
+	// each new value evicts exactly one entry, checked without using any
+	steps := []struct {
+		key       string
+		remaining []string
+	}{
+		{key: "https://example.com/e", remaining: []string{"https://example.com/e", "https://example.com/d", "https://example.com/a"}},
+		{key: "https://example.com/f", remaining: []string{"https://example.com/f", "https://example.com/e", "https://example.com/d"}},
+		{key: "https://example.com/g", remaining: []string{"https://example.com/g", "https://example.com/f", "https://example.com/e"}},
+	}
+	for _, step := range steps {
+		cache.Add(step.key, []byte("xxxx"))
+		keys := []string{}
+		for _, entry := range cache.Entries() {
+			keys = append(keys, entry.Key)
+		}
+		if !slices.Equal(keys, step.remaining) {
+			t.Errorf("keys after adding %s do not match: %v vs %v", step.key, keys, step.remaining)
+		}
+	}
+}
+
+func TestSizeAccounting(t *testing.T) {
+	cache := NewBoundedCache(interval, 10)
+	defer cache.Close()
+	cache.Add("https://example.com", []byte("12345"))
+	cache.Add("https://example.com", []byte("123"))
+	if cache.Size() != 3 {
+		t.Errorf("expected replacing a value to update the size, got %v", cache.Size())
+	}
+
+	cache.Add("https://example.com/big", []byte("12345678901"))
+	if _, ok := cache.Get("https://example.com/big"); ok {
+		t.Errorf("expected a value larger than the cache not to be stored")
+	}
+	if _, ok := cache.Get("https://example.com"); !ok {
+		t.Errorf("expected a value larger than the cache not to evict others")
+	}
+}
+
+func TestReapUpdatesSize(t *testing.T) {
+	const baseTime = 5 * time.Millisecond
+	cache := NewBoundedCache(baseTime, 100)
+	defer cache.Close()
+	cache.Add("https://example.com", []byte("testdata"))
+
+	time.Sleep(baseTime * 3)
+
+	if cache.Size() != 0 {
+		t.Errorf("expected reaped entries to free their bytes, got %v", cache.Size())
+	}
+}
+
+func TestStats(t *testing.T) {
+	cache := NewBoundedCache(interval, 8)
+	defer cache.Close()
+	cache.Add("https://example.com/a", []byte("aaaa"))
+	cache.Add("https://example.com/b", []byte("bbbb"))
+	cache.Get("https://example.com/a")
+	cache.Get("https://example.com/missing")
+	cache.Add("https://example.com/c", []byte("cccc"))
+
+	stats := cache.Stats()
+	expected := Stats{Hits: 1, Misses: 1, Evictions: 1, Entries: 2, Bytes: 8, MaxBytes: 8}
+	if stats != expected {
+		t.Errorf("The stats do not match: %+v vs %+v", stats, expected)
+	}
+
+	entries := cache.Entries()
+	if len(entries) != 2 || entries[0].Key != "https://example.com/c" || entries[1].Key != "https://example.com/a" {
+		t.Errorf("expected entries from most to least recently used: %+v", entries)
+	}
+
+	if !cache.Remove("https://example.com/a") || cache.Remove("https://example.com/a") {
+		t.Errorf("expected Remove to report whether the key was cached")
+	}
+	cache.Clear()
+	if stats := cache.Stats(); stats.Entries != 0 || stats.Bytes != 0 || stats.Hits != 1 {
+		t.Errorf("expected Clear to empty the cache but keep the counters: %+v", stats)
+	}
+}
+
+func TestCloseStopsReaper(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	caches := []*Cache{}
+	for i := 0; i < 10; i++ {
+		caches = append(caches, NewCache(time.Millisecond))
+	}
+	for _, cache := range caches {
+		cache.Close()
+		cache.Close()
+	}
+
+	// a reaper may still be returning after closing its stopped channel
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("expected every reaper goroutine to exit: %v before, %v after", before, after)
+	}
+}
+
+func TestUseAfterClose(t *testing.T) {
+	cache := NewCache(interval)
+	cache.Close()
+	cache.Add("https://example.com", []byte("testdata"))
+	if _, ok := cache.Get("https://example.com"); !ok {
+		t.Errorf("expected a closed cache to keep working")
+	}
+}
+
+func TestAddWithTTL(t *testing.T) {
+	cache := NewCache(interval)
+	defer cache.Close()
+	cache.AddWithTTL("https://example.com/short", []byte("testdata"), 5*time.Millisecond, 0)
+	cache.AddWithTTL("https://example.com/long", []byte("testdata"), time.Hour, 0)
+
+	_, expiry, ok := cache.GetWithExpiry("https://example.com/long")
+	if !ok {
+		t.Errorf("expected to find key")
+		return
+	}
+	if expiry.Stale || time.Until(expiry.ExpiresAt) < 59*time.Minute {
+		t.Errorf("expected the entry's own time to live: %+v", expiry)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+
+	if _, ok := cache.Get("https://example.com/short"); ok {
+		t.Errorf("expected the short lived key to expire before the cache interval")
+	}
+	if _, ok := cache.Get("https://example.com/long"); !ok {
+		t.Errorf("expected the long lived key to remain")
+	}
+}
+
+func TestStaleWhileRevalidate(t *testing.T) {
+	cache := NewCache(interval)
+	defer cache.Close()
+	cache.AddWithTTL("https://example.com", []byte("testdata"), 5*time.Millisecond, 20*time.Millisecond)
+
+	time.Sleep(10 * time.Millisecond)
+
+	val, expiry, ok := cache.GetWithExpiry("https://example.com")
+	if !ok || string(val) != "testdata" {
+		t.Errorf("expected the stale value to still be served")
+		return
+	}
+	if !expiry.Stale {
+		t.Errorf("expected the value to be reported as stale")
+	}
+	if stats := cache.Stats(); stats.StaleHits != 1 || stats.Hits != 0 {
+		t.Errorf("expected a stale hit: %+v", stats)
+	}
+
+	// refreshing the entry makes it fresh again
+	cache.AddWithTTL("https://example.com", []byte("newdata"), time.Hour, 0)
+	val, expiry, ok = cache.GetWithExpiry("https://example.com")
+	if !ok || expiry.Stale || string(val) != "newdata" {
+		t.Errorf("expected the refreshed value: %q %+v", val, expiry)
+	}
+}
+
+func TestRevalidate(t *testing.T) {
+	cache := NewCache(interval)
+	defer cache.Close()
+	validators := Validators{ETag: `"abc"`, LastModified: "Mon, 19 Oct 2026 00:00:00 GMT"}
+	cache.AddWithValidators("https://example.com", []byte("testdata"), 0, time.Hour, validators)
+
+	if stored, ok := cache.Validators("https://example.com"); !ok || stored != validators {
+		t.Errorf("expected the stored validators: %+v", stored)
+	}
+	if _, expiry, _ := cache.GetWithExpiry("https://example.com"); !expiry.Stale {
+		t.Errorf("expected the value to start out stale")
+	}
+
+	val, ok := cache.Revalidate("https://example.com", time.Hour, 0)
+	if !ok || string(val) != "testdata" {
+		t.Errorf("expected Revalidate to return the cached value")
+	}
+	if _, expiry, _ := cache.GetWithExpiry("https://example.com"); expiry.Stale {
+		t.Errorf("expected the value to be fresh after revalidating")
+	}
+	if _, ok := cache.Revalidate("https://example.com/missing", time.Hour, 0); ok {
+		t.Errorf("expected Revalidate to report a missing key")
+	}
+	if stats := cache.Stats(); stats.Revalidations != 1 {
+		t.Errorf("expected one revalidation: %+v", stats)
+	}
+}
+
+func TestCompression(t *testing.T) {
+	random := make([]byte, defaultCompressThreshold*2)
+	rand.New(rand.NewSource(1)).Read(random)
+
+	cases := []struct {
+		name       string
+		val        []byte
+		compressed bool
+	}{
+		{
+			name:       "small",
+			val:        []byte(`{"name":"bulbasaur"}`),
+			compressed: false,
+		},
+		{
+			name:       "large json",
+			val:        fixtures.PokemonJSON(100),
+			compressed: true,
+		},
+		{
+			name:       "incompressible",
+			val:        random,
+			compressed: false,
+		},
+	}
+
+	for _, c := range cases {
+		cache := NewCache(interval)
+		cache.Add(c.name, c.val)
+
+		val, ok := cache.Get(c.name)
+		if !ok || !bytes.Equal(val, c.val) {
+			t.Errorf("expected %s to round trip through the cache", c.name)
+		}
+		info := cache.Entries()[0]
+		if info.Compressed != c.compressed {
+			t.Errorf("compression of %s does not match: %v vs %v", c.name, info.Compressed, c.compressed)
+		}
+		if info.RawSize != len(c.val) || cache.Size() != info.Size {
+			t.Errorf("sizes of %s do not add up: %+v, cache size %v", c.name, info, cache.Size())
+		}
+		if c.compressed && info.Size >= info.RawSize {
+			t.Errorf("expected %s to take less space compressed: %+v", c.name, info)
+		}
+		cache.Close()
+	}
+}
+
+func TestRevalidateCompressed(t *testing.T) {
+	cache := NewCache(interval)
+	defer cache.Close()
+	data := fixtures.PokemonJSON(100)
+	cache.AddWithTTL("https://example.com", data, 0, time.Hour)
+
+	val, ok := cache.Revalidate("https://example.com", time.Hour, 0)
+	if !ok || !bytes.Equal(val, data) {
+		t.Errorf("expected Revalidate to return the decompressed value")
+	}
+}
+
+// benchmarkCompression adds and reads back a /pokemon sized response, reporting
+// how many bytes the cache holds for it so CPU time can be weighed against memory.
+func benchmarkCompression(b *testing.B, threshold int) {
+	data := fixtures.PokemonJSON(500)
+	cache := NewCache(time.Hour)
+	defer cache.Close()
+	cache.compressThreshold = threshold
+
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cache.Add("https://pokeapi.co/api/v2/pokemon/1/", data)
+		if _, ok := cache.Get("https://pokeapi.co/api/v2/pokemon/1/"); !ok {
+			b.Fatal("expected a cache hit")
+		}
+	}
+	b.ReportMetric(float64(cache.Size()), "stored-bytes")
+	b.ReportMetric(float64(len(data)), "raw-bytes")
+}
+
+func BenchmarkAddGetUncompressed(b *testing.B) {
+	benchmarkCompression(b, 0)
+}
+
+func BenchmarkAddGetCompressed(b *testing.B) {
+	benchmarkCompression(b, defaultCompressThreshold)
+}
+
+// BenchmarkGetCompressed measures the cost of a hit alone, which is paid on
+// every lookup while compressing is paid once per fetch.
+func BenchmarkGetCompressed(b *testing.B) {
+	data := fixtures.PokemonJSON(500)
+	cache := NewCache(time.Hour)
+	defer cache.Close()
+	cache.Add("https://pokeapi.co/api/v2/pokemon/1/", data)
+
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, ok := cache.Get("https://pokeapi.co/api/v2/pokemon/1/"); !ok {
+			b.Fatal("expected a cache hit")
+		}
+	}
+}
+
+func TestTypedCache(t *testing.T) {
+	type pokemon struct {
+		name  string
+		moves []string
+	}
+	cache := NewTypedCache[string, pokemon](2, time.Hour)
+	cache.Add("a", pokemon{name: "bulbasaur", moves: []string{"tackle"}})
+	cache.Add("b", pokemon{name: "ivysaur"})
+
+	// using a makes b the least recently used, so c evicts it
+	if val, ok := cache.Get("a"); !ok || val.name != "bulbasaur" || val.moves[0] != "tackle" {
+		t.Errorf("expected the cached value for a: %+v", val)
+	}
+	cache.Add("c", pokemon{name: "venusaur"})
+
+	cases := []struct {
+		key    string
+		exists bool
+	}{
+		{key: "a", exists: true},
+		{key: "b", exists: false},
+		{key: "c", exists: true},
+	}
+	for _, c := range cases {
+		if _, ok := cache.Get(c.key); ok != c.exists {
+			t.Errorf("presence of %s does not match: %v vs %v", c.key, ok, c.exists)
+		}
+	}
+	if cache.Len() != 2 {
+		t.Errorf("expected the cache to hold two values, got %v", cache.Len())
+	}
+
+	if !cache.Remove("a") || cache.Remove("a") {
+		t.Errorf("expected Remove to report whether there was a value")
+	}
+	cache.Clear()
+	if cache.Len() != 0 {
+		t.Errorf("expected Clear to empty the cache, got %v", cache.Len())
+	}
+}
+
+func TestTypedCacheTTL(t *testing.T) {
+	cache := NewTypedCache[int, string](10, time.Millisecond)
+	cache.Add(1, "bulbasaur")
+	time.Sleep(2 * time.Millisecond)
+	if _, ok := cache.Get(1); ok {
+		t.Errorf("expected the value to expire")
+	}
+	if cache.Len() != 0 {
+		t.Errorf("expected the expired value to be dropped, got %v", cache.Len())
+	}
+}
+
+func TestShardCount(t *testing.T) {
+	cases := []struct {
+		maxBytes int
+		shards   int
+	}{
+		{maxBytes: 0, shards: maxShards},
+		{maxBytes: 12, shards: 1},
+		{maxBytes: 4 * minShardBytes, shards: 4},
+		{maxBytes: 5 * minShardBytes, shards: 4},
+		{maxBytes: 256 << 20, shards: maxShards},
+	}
+
+	for _, c := range cases {
+		if shards := shardCount(c.maxBytes); shards != c.shards {
+			t.Errorf("shard counts for %v bytes do not match: %v vs %v", c.maxBytes, shards, c.shards)
+		}
+	}
+}
+
+func TestEntriesAcrossShards(t *testing.T) {
+	cache := NewCache(interval)
+	defer cache.Close()
+	keys := []string{}
+	for i := 0; i < 20; i++ {
+		keys = append(keys, fmt.Sprintf("https://example.com/%v", i))
+		cache.Add(keys[i], []byte("testdata"))
+	}
+	cache.Get(keys[0])
+
+	entries := cache.Entries()
+	if len(entries) != len(keys) {
+		t.Fatalf("expected an entry per key, got %v", len(entries))
+	}
+	if entries[0].Key != keys[0] {
+		t.Errorf("expected the key just used first: %v", entries[0].Key)
+	}
+	for i, entry := range entries[1:] {
+		if expected := keys[len(keys)-1-i]; entry.Key != expected {
+			t.Errorf("expected entries from most to least recently used: %v vs %v", entry.Key, expected)
+		}
+	}
+}
+
+func TestConcurrentUse(t *testing.T) {
+	const workers = 8
+	cache := NewBoundedCache(interval, 4*minShardBytes)
+	defer cache.Close()
+	cache.compressThreshold = 0
+	val := make([]byte, 64<<10)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				key := fmt.Sprintf("https://example.com/%v", (w*31+i)%200)
+				if i%4 == 0 {
+					cache.Add(key, val)
+				} else if data, ok := cache.Get(key); ok && len(data) != len(val) {
+					t.Errorf("expected the whole value for %s", key)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats := cache.Stats()
+	if stats.Evictions == 0 || stats.Bytes > stats.MaxBytes || stats.Bytes != stats.Entries*len(val) {
+		t.Errorf("expected the size to stay consistent and bounded: %+v", stats)
+	}
+	if stats.Hits+stats.Misses != workers*500*3/4 {
+		t.Errorf("expected every read to be counted: %+v", stats)
+	}
+}
+
+// benchmarkParallel runs a mix of reads and writes from GOMAXPROCS goroutines,
+// comparing a single shard, which behaves like one lock around the whole
+// cache, with the default number of shards.
+func benchmarkParallel(b *testing.B, writeEvery int) {
+	const keys = 1024
+	val := []byte(`{"name":"bulbasaur"}`)
+
+	for _, shards := range []int{1, maxShards} {
+		b.Run(fmt.Sprintf("shards=%v", shards), func(b *testing.B) {
+			cache := newShardedCache(time.Hour, 0, shards)
+			defer cache.Close()
+			urls := make([]string, keys)
+			for i := range urls {
+				urls[i] = fmt.Sprintf("https://pokeapi.co/api/v2/pokemon/%v/", i)
+				cache.Add(urls[i], val)
+			}
+
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				i := rand.Intn(keys)
+				for pb.Next() {
+					i = (i + 1) % keys
+					if writeEvery > 0 && i%writeEvery == 0 {
+						cache.Add(urls[i], val)
+						continue
+					}
+					cache.Get(urls[i])
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkGetParallel(b *testing.B) {
+	benchmarkParallel(b, 0)
+}
+
+// BenchmarkMixedParallel writes one request in ten, like prefetching into a
+// cache that is also being read.
+func BenchmarkMixedParallel(b *testing.B) {
+	benchmarkParallel(b, 10)
+}
+
+func TestTouch(t *testing.T) {
+	cache := NewBoundedCache(interval, 8)
+	defer cache.Close()
+	cache.Add("https://example.com/a", []byte("aaaa"))
+	cache.Add("https://example.com/b", []byte("bbbb"))
+
+	// touching a makes b the least recently used entry
+	if !cache.Touch("https://example.com/a") || cache.Touch("https://example.com/missing") {
+		t.Errorf("expected Touch to report whether the key is cached")
+	}
+	cache.Add("https://example.com/c", []byte("cccc"))
+	if _, ok := cache.Get("https://example.com/b"); ok {
+		t.Errorf("expected b to be evicted")
+	}
+	if stats := cache.Stats(); stats.Hits != 1 || stats.Misses != 1 {
+		t.Errorf("expected a touch to count as a hit: %+v", stats)
+	}
+}
+
+// BenchmarkEvictAfterReads reads every entry of a full cache before each
+// write, so every eviction first has to put used entries back in order.
+func BenchmarkEvictAfterReads(b *testing.B) {
+	const entries = 10000
+	val := []byte("testdata")
+	cache := newShardedCache(time.Hour, entries*len(val), 1)
+	defer cache.Close()
+	urls := make([]string, entries)
+	for i := range urls {
+		urls[i] = fmt.Sprintf("https://pokeapi.co/api/v2/pokemon/%v/", i)
+		cache.Add(urls[i], val)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, url := range urls[:100] {
+			cache.Get(url)
+		}
+		cache.Add(fmt.Sprintf("https://pokeapi.co/api/v2/pokemon-species/%v/", i), val)
+	}
+}
+
+func TestDiskCache(t *testing.T) {
+	dir := t.TempDir()
+	disk, err := NewDiskCache(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	small := []byte(`{"name":"bulbasaur"}`)
+	large := fixtures.PokemonJSON(100)
+	validators := Validators{ETag: `"abc"`}
+	if err := disk.Add("small", small, time.Hour, time.Hour, Validators{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := disk.Add("large", large, 0, time.Hour, validators); err != nil {
+		t.Fatal(err)
+	}
+	if stats := disk.Stats(); stats.Entries != 2 || stats.Bytes >= len(large) {
+		t.Errorf("expected the large value to be stored compressed: %+v", stats)
+	}
+
+	// a new session finds the values where the last one left them
+	disk, err = NewDiskCache(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	val, expiry, _, ok := disk.Get("small")
+	if !ok || !bytes.Equal(val, small) || expiry.Stale {
+		t.Errorf("expected the small value to be fresh: %q %+v", val, expiry)
+	}
+	val, expiry, gotValidators, ok := disk.Get("large")
+	if !ok || !bytes.Equal(val, large) || !expiry.Stale || gotValidators != validators {
+		t.Errorf("expected the large value to be stale with its validators: %+v %+v", expiry, gotValidators)
+	}
+	if _, _, _, ok := disk.Get("missing"); ok {
+		t.Error("expected a miss for a key that was never added")
+	}
+	if stats := disk.Stats(); stats.Hits != 2 || stats.Misses != 1 {
+		t.Errorf("unexpected counters: %+v", stats)
+	}
+
+	if !disk.Remove("small") || disk.Remove("small") {
+		t.Error("expected Remove to report whether the value was there")
+	}
+	if err := disk.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	if stats := disk.Stats(); stats.Entries != 0 || stats.Bytes != 0 {
+		t.Errorf("expected Clear to empty the cache: %+v", stats)
+	}
+}
+
+func TestDiskCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	disk, err := NewDiskCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	val := make([]byte, 100)
+	for _, key := range []string{"a", "b", "c"} {
+		if err := disk.Add(key, val, time.Hour, 0, Validators{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// modification times are the recency order, so spread them out explicitly
+	// rather than relying on the file system's timestamp resolution
+	base := time.Now().Add(-time.Hour)
+	for i, key := range []string{"b", "a", "c"} {
+		at := base.Add(time.Duration(i) * time.Minute)
+		os.Chtimes(disk.path(key), at, at)
+	}
+
+	disk.maxBytes = disk.Stats().Bytes * 2 / 3
+	disk.Add("c", val, time.Hour, 0, Validators{})
+	if _, _, _, ok := disk.Get("b"); ok {
+		t.Error("expected the least recently used value to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, _, _, ok := disk.Get(key); !ok {
+			t.Errorf("expected %s to be kept", key)
+		}
+	}
+}
+
+func BenchmarkDiskGet(b *testing.B) {
+	data := fixtures.PokemonJSON(500)
+	disk, err := NewDiskCache(b.TempDir(), 0)
+	if err != nil {
+		b.Fatal(err)
+	}
+	disk.Add("https://pokeapi.co/api/v2/pokemon/1/", data, time.Hour, 0, Validators{})
+
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, _, ok := disk.Get("https://pokeapi.co/api/v2/pokemon/1/"); !ok {
+			b.Fatal("expected a disk hit")
+		}
+	}
+	b.ReportMetric(float64(disk.Stats().Bytes), "stored-bytes")
+}
@@ -0,0 +1,146 @@
+package pokecache
+
+import (
+	"container/heap"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// maxShards is how many shards a cache is split into, a power of two
+	maxShards = 16
+	// minShardBytes keeps small bounded caches from being split into shards too
+	// small to hold a typical response, since each shard gets an equal share.
+	minShardBytes = 1 << 20
+)
+
+// shard is one independently locked part of a cache. Reads only take the read
+// lock: they stamp the entry with the tick it was used at instead of moving it
+// in the recency heap, and evict puts entries back in order before evicting.
+type shard struct {
+	entries map[string]*cacheEntry
+	mux     *sync.RWMutex
+	// recency is a min-heap of the entries by listedAt
+	recency  recencyHeap
+	size     int
+	maxBytes int
+	counters counters
+}
+
+// counters are updated atomically so reads can count under the read lock.
+type counters struct {
+	hits          atomic.Int64
+	staleHits     atomic.Int64
+	revalidations atomic.Int64
+	misses        atomic.Int64
+	evictions     atomic.Int64
+	expired       atomic.Int64
+}
+
+func newShard(maxBytes int) *shard {
+	return &shard{
+		entries:  make(map[string]*cacheEntry),
+		mux:      &sync.RWMutex{},
+		maxBytes: maxBytes,
+	}
+}
+
+// shardCount splits a cache into as many shards as it can while giving each
+// at least minShardBytes. Unbounded caches always get maxShards.
+func shardCount(maxBytes int) int {
+
+	count := maxShards
+	for maxBytes > 0 && count > 1 && maxBytes/count < minShardBytes {
+		count /= 2
+	}
+	return count
+}
+
+// shardIndex hashes key with 32-bit FNV-1a, inlined so lookups do not allocate.
+func shardIndex(key string, shards int) int {
+
+	const (
+		offset32 = 2166136261
+		prime32  = 16777619
+	)
+	hash := uint32(offset32)
+	for i := 0; i < len(key); i++ {
+		hash ^= uint32(key[i])
+		hash *= prime32
+	}
+	return int(hash & uint32(shards-1))
+}
+
+// add stores entry, stamped with now, and evicts to make room for it. The
+// caller must hold the lock.
+func (s *shard) add(entry *cacheEntry, now int64) {
+
+	s.remove(entry.key)
+	if s.maxBytes > 0 && len(entry.val) > s.maxBytes {
+		// it would only evict everything else and then itself
+		return
+	}
+	entry.listedAt = now
+	entry.lastUsed.Store(now)
+	heap.Push(&s.recency, entry)
+	s.entries[entry.key] = entry
+	s.size += len(entry.val)
+	s.evict()
+}
+
+// evict removes least recently used entries until the shard fits in maxBytes.
+// An entry used since it was listed is moved to its place by lastUsed first,
+// so the top of the heap is always the least recently used entry. The caller
+// must hold the lock.
+func (s *shard) evict() {
+
+	for s.maxBytes > 0 && s.size > s.maxBytes && len(s.recency) > 0 {
+		entry := s.recency[0]
+		if used := entry.lastUsed.Load(); used > entry.listedAt {
+			entry.listedAt = used
+			heap.Fix(&s.recency, entry.index)
+			continue
+		}
+		s.remove(entry.key)
+		s.counters.evictions.Add(1)
+	}
+}
+
+// remove deletes an entry and its bookkeeping. The caller must hold the lock.
+func (s *shard) remove(key string) {
+
+	entry, exists := s.entries[key]
+	if !exists {
+		return
+	}
+	heap.Remove(&s.recency, entry.index)
+	s.size -= len(entry.val)
+	delete(s.entries, key)
+}
+
+// recencyHeap orders entries by listedAt, oldest first, and keeps each entry's
+// index up to date so it can be fixed or removed in place.
+type recencyHeap []*cacheEntry
+
+func (h recencyHeap) Len() int           { return len(h) }
+func (h recencyHeap) Less(i, j int) bool { return h[i].listedAt < h[j].listedAt }
+
+func (h recencyHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *recencyHeap) Push(x any) {
+	entry := x.(*cacheEntry)
+	entry.index = len(*h)
+	*h = append(*h, entry)
+}
+
+func (h *recencyHeap) Pop() any {
+	old := *h
+	entry := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return entry
+}
@@ -0,0 +1,126 @@
+package pokecache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type typedEntry[K comparable, V any] struct {
+	key       K
+	val       V
+	expiresAt time.Time
+}
+
+// TypedCache holds decoded values, such as unmarshaled responses, so a hit
+// skips decoding entirely. Unlike Cache it bounds the number of entries rather
+// than their size, since the size of a decoded value is unknown, and expired
+// entries are dropped when they are looked up instead of by a reaper goroutine.
+//
+// Values are returned as stored, so any slices or maps they hold are shared
+// between callers and must not be modified.
+type TypedCache[K comparable, V any] struct {
+	entries map[K]*list.Element
+	mux     *sync.Mutex
+	// recency holds the entries from most to least recently used
+	recency    *list.List
+	maxEntries int
+	ttl        time.Duration
+}
+
+// NewTypedCache returns a cache of at most maxEntries values, each kept for ttl.
+// The least recently used value is evicted to make room for a new one.
+func NewTypedCache[K comparable, V any](maxEntries int, ttl time.Duration) *TypedCache[K, V] {
+	return &TypedCache[K, V]{
+		entries:    make(map[K]*list.Element),
+		mux:        &sync.Mutex{},
+		recency:    list.New(),
+		maxEntries: maxEntries,
+		ttl:        ttl,
+	}
+}
+
+// Add caches val under key for the cache's time to live, replacing any value
+// already there.
+func (c *TypedCache[K, V]) Add(key K, val V) {
+	c.AddWithExpiry(key, val, time.Now().UTC().Add(c.ttl))
+}
+
+// AddWithExpiry is like Add but keeps val only until expiresAt, for values
+// decoded from data that expires at a known time.
+func (c *TypedCache[K, V]) AddWithExpiry(key K, val V, expiresAt time.Time) {
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.remove(key)
+	if c.maxEntries <= 0 {
+		return
+	}
+	for c.recency.Len() >= c.maxEntries {
+		c.remove(c.recency.Back().Value.(*typedEntry[K, V]).key)
+	}
+	entry := &typedEntry[K, V]{
+		key:       key,
+		val:       val,
+		expiresAt: expiresAt,
+	}
+	c.entries[key] = c.recency.PushFront(entry)
+}
+
+// Get returns the value for key, or false if there is none or it has expired.
+func (c *TypedCache[K, V]) Get(key K) (V, bool) {
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	element, exists := c.entries[key]
+	if !exists {
+		var zero V
+		return zero, false
+	}
+	entry := element.Value.(*typedEntry[K, V])
+	if !time.Now().UTC().Before(entry.expiresAt) {
+		c.remove(key)
+		var zero V
+		return zero, false
+	}
+	c.recency.MoveToFront(element)
+	return entry.val, true
+}
+
+// Remove deletes the value for key and reports whether there was one.
+func (c *TypedCache[K, V]) Remove(key K) bool {
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	_, exists := c.entries[key]
+	c.remove(key)
+	return exists
+}
+
+// Clear deletes every value.
+func (c *TypedCache[K, V]) Clear() {
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.entries = make(map[K]*list.Element)
+	c.recency.Init()
+}
+
+// Len returns the number of values held, including expired ones not yet dropped.
+func (c *TypedCache[K, V]) Len() int {
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return len(c.entries)
+}
+
+// remove deletes an entry and its bookkeeping. The caller must hold the lock.
+func (c *TypedCache[K, V]) remove(key K) {
+
+	element, exists := c.entries[key]
+	if !exists {
+		return
+	}
+	c.recency.Remove(element)
+	delete(c.entries, key)
+}
@@ -0,0 +1,143 @@
+package sprite
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"strings"
+)
+
+// ColorMode selects the escape sequences used to color the rendered sprite.
+type ColorMode int
+
+const (
+	Color256 ColorMode = iota
+	TrueColor
+)
+
+const (
+	upperHalfBlock = "▀"
+	lowerHalfBlock = "▄"
+	resetColor     = "\x1b[0m"
+	// alphaThreshold is the alpha below which a pixel is treated as transparent.
+	alphaThreshold = 0x8000
+)
+
+// DetectColorMode returns TrueColor when the terminal advertises 24-bit color
+// support and falls back to the 256-color palette otherwise.
+func DetectColorMode() ColorMode {
+
+	colorTerm := strings.ToLower(os.Getenv("COLORTERM"))
+	if colorTerm == "truecolor" || colorTerm == "24bit" {
+		return TrueColor
+	}
+	return Color256
+}
+
+// RenderPNG decodes PNG data and renders it with Render.
+func RenderPNG(data []byte, width int, mode ColorMode) (string, error) {
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	return Render(img, width, mode), nil
+}
+
+// Render draws img using half-block characters, two pixel rows per line of
+// text. Transparent padding is cropped and the image is scaled down so it is at
+// most width columns wide.
+func Render(img image.Image, width int, mode ColorMode) string {
+
+	bounds := opaqueBounds(img)
+	if bounds.Empty() || width < 1 {
+		return ""
+	}
+
+	scale := 1
+	if bounds.Dx() > width {
+		scale = (bounds.Dx() + width - 1) / width
+	}
+	cols := (bounds.Dx() + scale - 1) / scale
+	rows := (bounds.Dy() + scale - 1) / scale
+
+	var sb strings.Builder
+	for y := 0; y < rows; y += 2 {
+		for x := 0; x < cols; x++ {
+			top, topOK := sample(img, bounds, x, y, scale)
+			bottom, bottomOK := sample(img, bounds, x, y+1, scale)
+			switch {
+			case topOK && bottomOK:
+				sb.WriteString(foreground(top, mode) + background(bottom, mode) + upperHalfBlock + resetColor)
+			case topOK:
+				sb.WriteString(foreground(top, mode) + upperHalfBlock + resetColor)
+			case bottomOK:
+				sb.WriteString(foreground(bottom, mode) + lowerHalfBlock + resetColor)
+			default:
+				sb.WriteString(" ")
+			}
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// opaqueBounds returns the smallest rectangle containing every visible pixel.
+func opaqueBounds(img image.Image) image.Rectangle {
+
+	b := img.Bounds()
+	found := image.Rectangle{}
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if _, _, _, a := img.At(x, y).RGBA(); a < alphaThreshold {
+				continue
+			}
+			found = found.Union(image.Rect(x, y, x+1, y+1))
+		}
+	}
+	return found
+}
+
+// sample returns the top-left pixel of the scale x scale block at the given
+// output position, and whether it is visible.
+func sample(img image.Image, bounds image.Rectangle, x, y, scale int) (color.RGBA, bool) {
+
+	px := bounds.Min.X + x*scale
+	py := bounds.Min.Y + y*scale
+	if px >= bounds.Max.X || py >= bounds.Max.Y {
+		return color.RGBA{}, false
+	}
+	r, g, b, a := img.At(px, py).RGBA()
+	if a < alphaThreshold {
+		return color.RGBA{}, false
+	}
+	return color.RGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: 0xff}, true
+}
+
+func foreground(c color.RGBA, mode ColorMode) string {
+
+	if mode == TrueColor {
+		return fmt.Sprintf("\x1b[38;2;%d;%d;%dm", c.R, c.G, c.B)
+	}
+	return fmt.Sprintf("\x1b[38;5;%dm", to256(c))
+}
+
+func background(c color.RGBA, mode ColorMode) string {
+
+	if mode == TrueColor {
+		return fmt.Sprintf("\x1b[48;2;%d;%d;%dm", c.R, c.G, c.B)
+	}
+	return fmt.Sprintf("\x1b[48;5;%dm", to256(c))
+}
+
+// to256 maps a color onto the 6x6x6 cube of the xterm 256-color palette.
+func to256(c color.RGBA) int {
+
+	level := func(v uint8) int {
+		return (int(v)*5 + 127) / 255
+	}
+	return 16 + 36*level(c.R) + 6*level(c.G) + level(c.B)
+}
@@ -0,0 +1,62 @@
+package sprite
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+func TestRenderCropsTransparentPadding(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	img.Set(4, 4, color.RGBA{R: 255, A: 255})
+	img.Set(5, 4, color.RGBA{G: 255, A: 255})
+	img.Set(4, 5, color.RGBA{B: 255, A: 255})
+
+	output := Render(img, 40, TrueColor)
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+	if len(lines) != 1 {
+		t.Errorf("expected 1 line, got %v", len(lines))
+		return
+	}
+	if !strings.Contains(lines[0], "\x1b[38;2;255;0;0m\x1b[48;2;0;0;255m"+upperHalfBlock) {
+		t.Errorf("expected red over blue half block: %q", lines[0])
+	}
+	if !strings.Contains(lines[0], "\x1b[38;2;0;255;0m"+upperHalfBlock) {
+		t.Errorf("expected a green top-only half block: %q", lines[0])
+	}
+}
+
+func TestRenderEmpty(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	if output := Render(img, 40, Color256); output != "" {
+		t.Errorf("expected no output for a transparent image: %q", output)
+	}
+}
+
+func TestTo256(t *testing.T) {
+	cases := []struct {
+		input    color.RGBA
+		expected int
+	}{
+		{
+			input:    color.RGBA{A: 255},
+			expected: 16,
+		},
+		{
+			input:    color.RGBA{R: 255, G: 255, B: 255, A: 255},
+			expected: 231,
+		},
+		{
+			input:    color.RGBA{R: 255, A: 255},
+			expected: 196,
+		},
+	}
+
+	for _, cs := range cases {
+		actual := to256(cs.input)
+		if actual != cs.expected {
+			t.Errorf("The colors do not match: %v vs %v", actual, cs.expected)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/aspiringVegetarian/PokedexCLI/internal/pokeapi"
+)
+
+// listSortKeys are the values accepted by the --sort flag of pokedex and team.
+var listSortKeys = []string{"id", "name", "caught-at", "type", "bst"}
+
+// pokedexEntry is one Pokemon in a pokedex or team listing. owned is nil for
+// Pokemon that have been seen but not caught.
+type pokedexEntry struct {
+	name      string
+	dexNumber int
+	info      pokeapi.SpecificPokemonResp
+	owned     *ownedPokemon
+}
+
+// listEntries returns the Pokedex entries matching the --type and --caught
+// flags, ordered by the --sort flag. Listings are always in the same order, by
+// national dex number unless another order is asked for.
+func listEntries(cfg *config, flags map[string]string) ([]pokedexEntry, error) {
+
+	sortKey, ok := flags["sort"]
+	if !ok {
+		sortKey = "id"
+	}
+	if !slices.Contains(listSortKeys, sortKey) {
+		return nil, fmt.Errorf("Unknown sort order %s. Use id, name, caught-at, type or bst", sortKey)
+	}
+	filterType, filterByType := flags["type"]
+	_, caughtOnly := flags["caught"]
+
+	entries := []pokedexEntry{}
+	for name, info := range cfg.pokedexSeen {
+		owned := cfg.pokedexCaught[name]
+		if caughtOnly && owned == nil {
+			continue
+		}
+		if filterByType && !hasTypes(info, []string{filterType}) {
+			continue
+		}
+		entries = append(entries, pokedexEntry{
+			name:      name,
+			dexNumber: dexNumber(info),
+			info:      info,
+			owned:     owned,
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		switch sortKey {
+		case "name":
+			if a.name != b.name {
+				return a.name < b.name
+			}
+		case "caught-at":
+			// Pokemon that haven't been caught go last
+			if (a.owned == nil) != (b.owned == nil) {
+				return a.owned != nil
+			}
+			if a.owned != nil && !a.owned.caughtAt.Equal(b.owned.caughtAt) {
+				return a.owned.caughtAt.Before(b.owned.caughtAt)
+			}
+		case "type":
+			aType, bType := primaryType(a.info), primaryType(b.info)
+			if aType != bType {
+				return aType < bType
+			}
+		case "bst":
+			aTotal, bTotal := baseStatTotal(a.info), baseStatTotal(b.info)
+			if aTotal != bTotal {
+				return aTotal > bTotal
+			}
+		}
+		if a.dexNumber != b.dexNumber {
+			return a.dexNumber < b.dexNumber
+		}
+		return a.name < b.name
+	})
+
+	return entries, nil
+}
+
+// dexNumber returns the national dex number of the Pokemon's species, so
+// alternate forms share the number of their base form.
+func dexNumber(info pokeapi.SpecificPokemonResp) int {
+
+	id, err := pokeapi.ResourceID(info.Species.URL)
+	if err != nil {
+		return info.ID
+	}
+	return id
+}
+
+func primaryType(info pokeapi.SpecificPokemonResp) string {
+
+	for _, content := range info.Types {
+		if content.Slot == 1 {
+			return content.Type.Name
+		}
+	}
+	return ""
+}
+
+func baseStatTotal(info pokeapi.SpecificPokemonResp) int {
+
+	total := 0
+	for _, content := range info.Stats {
+		total += content.BaseStat
+	}
+	return total
+}
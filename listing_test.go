@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aspiringVegetarian/PokedexCLI/internal/pokeapi"
+)
+
+func testListConfig(t *testing.T) *config {
+	cfg := &config{
+		pokedexSeen:   make(map[string]pokeapi.SpecificPokemonResp),
+		pokedexCaught: make(map[string]*ownedPokemon),
+	}
+	pokemon := map[string]string{
+		"charmander": `{"name": "charmander", "species": {"url": "https://pokeapi.co/api/v2/pokemon-species/4/"},
+			"types": [{"slot": 1, "type": {"name": "fire"}}], "stats": [{"base_stat": 309}]}`,
+		"bulbasaur": `{"name": "bulbasaur", "species": {"url": "https://pokeapi.co/api/v2/pokemon-species/1/"},
+			"types": [{"slot": 1, "type": {"name": "grass"}}, {"slot": 2, "type": {"name": "poison"}}], "stats": [{"base_stat": 318}]}`,
+		"arcanine": `{"name": "arcanine", "species": {"url": "https://pokeapi.co/api/v2/pokemon-species/59/"},
+			"types": [{"slot": 1, "type": {"name": "fire"}}], "stats": [{"base_stat": 555}]}`,
+	}
+	for name, data := range pokemon {
+		info := pokeapi.SpecificPokemonResp{}
+		if err := json.Unmarshal([]byte(data), &info); err != nil {
+			t.Fatal(err)
+		}
+		cfg.pokedexSeen[name] = info
+	}
+	now := time.Now()
+	cfg.pokedexCaught["arcanine"] = &ownedPokemon{species: "arcanine", caughtAt: now}
+	cfg.pokedexCaught["charmander"] = &ownedPokemon{species: "charmander", caughtAt: now.Add(time.Minute)}
+	return cfg
+}
+
+func TestListEntries(t *testing.T) {
+	cases := []struct {
+		flags    map[string]string
+		expected []string
+	}{
+		{
+			flags:    map[string]string{},
+			expected: []string{"bulbasaur", "charmander", "arcanine"},
+		},
+		{
+			flags:    map[string]string{"sort": "name"},
+			expected: []string{"arcanine", "bulbasaur", "charmander"},
+		},
+		{
+			flags:    map[string]string{"sort": "caught-at"},
+			expected: []string{"arcanine", "charmander", "bulbasaur"},
+		},
+		{
+			flags:    map[string]string{"sort": "bst"},
+			expected: []string{"arcanine", "bulbasaur", "charmander"},
+		},
+		{
+			flags:    map[string]string{"sort": "type"},
+			expected: []string{"charmander", "arcanine", "bulbasaur"},
+		},
+		{
+			flags:    map[string]string{"type": "fire", "caught": "true"},
+			expected: []string{"charmander", "arcanine"},
+		},
+	}
+
+	cfg := testListConfig(t)
+	for _, cs := range cases {
+		entries, err := listEntries(cfg, cs.flags)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != len(cs.expected) {
+			t.Errorf("The lengths are not equal for %v: %v vs %v", cs.flags, len(entries), len(cs.expected))
+			continue
+		}
+		for i := range entries {
+			if entries[i].name != cs.expected[i] {
+				t.Errorf("The order does not match for %v: %v vs %v", cs.flags, entries[i].name, cs.expected[i])
+			}
+		}
+	}
+}
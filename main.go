@@ -1,30 +1,92 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/aspiringVegetarian/PokedexCLI/internal/pokeapi"
+	"github.com/aspiringVegetarian/PokedexCLI/internal/sprite"
 )
 
+// defaultShinyRate is the 1 in N chance of a shiny encounter used since generation VI.
+const defaultShinyRate = 4096
+
 type config struct {
 	pokeapiClient       pokeapi.Client
 	pokedexSeen         map[string]pokeapi.SpecificPokemonResp
-	pokedexCaught       map[string]string
+	pokedexCaught       map[string]*ownedPokemon
+	shinySeen           map[string]int
+	inventory           map[string]int
+	searchIndex         map[string]searchEntry
+	searchIndexComplete bool
+	shinyRate           int
+	showSprites         bool
+	spriteColorMode     sprite.ColorMode
+	language            string
 	nextLocationAreaURL *string
 	prevLocationAreaURL *string
 	locationCount       *int
-	specificLocation    *string
+	mapRegion           string
+	regionAreas         []string
+	regionAreaOffset    int
 	nextPokemonURL      *string
 	prevPokemonURL      *string
 	pokemonCount        *int
-	specificPokemon     *string
+	nextItemURL         *string
+	prevItemURL         *string
+	itemCategory        string
+	categoryItems       []string
+	categoryItemOffset  int
+	nextBerryURL        *string
+	prevBerryURL        *string
 }
 
 func main() {
-	cfg := config{
-		pokeapiClient: pokeapi.NewClient(time.Minute),
-		pokedexSeen:   make(map[string]pokeapi.SpecificPokemonResp),
-		pokedexCaught: make(map[string]string),
+	shinyRate := flag.Int("shiny-rate", defaultShinyRate, "1 in N chance that a wild Pokemon is shiny")
+	showSprites := flag.Bool("sprites", true, "draw Pokemon sprites in the terminal")
+	language := flag.String("language", "en", "language code used for names and descriptions, such as en, de or ja")
+	cacheDir := flag.String("cache-dir", defaultCacheDir(), "directory that keeps API responses between sessions, or empty to keep them in memory only")
+	flag.Parse()
+	if *shinyRate < 1 {
+		*shinyRate = 1
+	}
+
+	client := pokeapi.NewClient(time.Minute)
+	if *cacheDir != "" {
+		if err := client.UseDiskCache(*cacheDir); err != nil {
+			fmt.Printf("Responses will not be kept between sessions: %v\n", err)
+		}
 	}
+	cfg := newConfig(client, *shinyRate, *showSprites, *language)
 	startRepl(&cfg)
 }
+
+// defaultCacheDir is pokedexcli in the user's cache directory, or empty if
+// there is none.
+func defaultCacheDir() string {
+
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "pokedexcli")
+}
+
+// newConfig returns the state the REPL starts with, every map ready to use.
+func newConfig(client pokeapi.Client, shinyRate int, showSprites bool, language string) config {
+	return config{
+		pokeapiClient:   client,
+		pokedexSeen:     make(map[string]pokeapi.SpecificPokemonResp),
+		pokedexCaught:   make(map[string]*ownedPokemon),
+		shinySeen:       make(map[string]int),
+		inventory:       make(map[string]int),
+		searchIndex:     make(map[string]searchEntry),
+		shinyRate:       shinyRate,
+		showSprites:     showSprites,
+		spriteColorMode: sprite.DetectColorMode(),
+		language:        language,
+	}
+}
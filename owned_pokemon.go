@@ -0,0 +1,144 @@
+package main
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/aspiringVegetarian/PokedexCLI/internal/pokeapi"
+)
+
+const (
+	maxIV          = 31
+	maxKnownMoves  = 4
+	maxStatEV      = 252
+	maxTotalEV     = 510
+	minWildLevel   = 2
+	maxWildLevel   = 50
+	natureIncrease = 1.1
+	natureDecrease = 0.9
+	// defaultNature is used when no nature can be fetched; like every neutral
+	// nature it changes no stats
+	defaultNature = "hardy"
+)
+
+// statNames lists the stats in the order the games display them.
+var statNames = []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"}
+
+// ownedPokemon is a single caught Pokemon. Unlike the species data in the
+// Pokedex, every owned Pokemon has its own IVs, EVs, nature and level.
+type ownedPokemon struct {
+	species       string
+	nickname      string
+	level         int
+	nature        string
+	increasedStat string
+	decreasedStat string
+	shiny         bool
+	moves         []string
+	heldItem      string
+	caughtAt      time.Time
+	ivs           map[string]int
+	evs           map[string]int
+}
+
+func newOwnedPokemon(info pokeapi.SpecificPokemonResp, nickname string, nature pokeapi.SpecificNatureResp) *ownedPokemon {
+
+	owned := &ownedPokemon{
+		species:  info.Name,
+		nickname: nickname,
+		level:    rand.Intn(maxWildLevel-minWildLevel+1) + minWildLevel,
+		nature:   nature.Name,
+		ivs:      make(map[string]int),
+		evs:      make(map[string]int),
+		caughtAt: time.Now(),
+	}
+	owned.moves = knownMoves(info, owned.level)
+	if nature.IncreasedStat != nil {
+		owned.increasedStat = nature.IncreasedStat.Name
+	}
+	if nature.DecreasedStat != nil {
+		owned.decreasedStat = nature.DecreasedStat.Name
+	}
+	for _, stat := range statNames {
+		owned.ivs[stat] = rand.Intn(maxIV + 1)
+	}
+	return owned
+}
+
+// gainEVs adds the effort values yielded by a defeated Pokemon, respecting the
+// per-stat and total EV caps.
+func (p *ownedPokemon) gainEVs(defeated pokeapi.SpecificPokemonResp) {
+
+	total := 0
+	for _, ev := range p.evs {
+		total += ev
+	}
+	for _, content := range defeated.Stats {
+		gain := min(content.Effort, maxStatEV-p.evs[content.Stat.Name], maxTotalEV-total)
+		if gain <= 0 {
+			continue
+		}
+		p.evs[content.Stat.Name] += gain
+		total += gain
+	}
+}
+
+// natureMultiplier returns how the Pokemon's nature affects the given stat.
+func (p *ownedPokemon) natureMultiplier(stat string) float64 {
+
+	if p.increasedStat == p.decreasedStat {
+		return 1
+	}
+	switch stat {
+	case p.increasedStat:
+		return natureIncrease
+	case p.decreasedStat:
+		return natureDecrease
+	}
+	return 1
+}
+
+// actualStat computes a stat with the formula used by the games from
+// generation III onward.
+func (p *ownedPokemon) actualStat(stat string, baseStat int) int {
+
+	core := (2*baseStat + p.ivs[stat] + p.evs[stat]/4) * p.level / 100
+	if stat == "hp" {
+		return core + p.level + 10
+	}
+	return int(float64(core+5) * p.natureMultiplier(stat))
+}
+
+// knownMoves returns the moves a wild Pokemon of the given level knows: like in
+// the games, the last few moves it learned by leveling up. The most recent
+// version group listed for each move decides the level it is learned at.
+func knownMoves(info pokeapi.SpecificPokemonResp, level int) []string {
+
+	type learnedMove struct {
+		name  string
+		level int
+	}
+	learned := []learnedMove{}
+	for _, content := range info.Moves {
+		for i := len(content.VersionGroupDetails) - 1; i >= 0; i-- {
+			details := content.VersionGroupDetails[i]
+			if details.MoveLearnMethod.Name != "level-up" {
+				continue
+			}
+			if details.LevelLearnedAt <= level {
+				learned = append(learned, learnedMove{name: content.Move.Name, level: details.LevelLearnedAt})
+			}
+			break
+		}
+	}
+	sort.SliceStable(learned, func(i, j int) bool {
+		return learned[i].level < learned[j].level
+	})
+
+	moves := []string{}
+	for _, move := range learned[max(0, len(learned)-maxKnownMoves):] {
+		moves = append(moves, move.name)
+	}
+	return moves
+}
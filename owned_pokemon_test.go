@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aspiringVegetarian/PokedexCLI/internal/pokeapi"
+)
+
+func TestActualStat(t *testing.T) {
+	// Garchomp example from the official stat formula documentation
+	garchomp := &ownedPokemon{
+		level:         78,
+		increasedStat: "attack",
+		decreasedStat: "special-attack",
+		ivs:           map[string]int{"hp": 24, "attack": 12, "special-attack": 16},
+		evs:           map[string]int{"hp": 74, "attack": 190, "special-attack": 48},
+	}
+
+	cases := []struct {
+		stat     string
+		baseStat int
+		expected int
+	}{
+		{
+			stat:     "hp",
+			baseStat: 108,
+			expected: 289,
+		},
+		{
+			stat:     "attack",
+			baseStat: 130,
+			expected: 278,
+		},
+		{
+			stat:     "special-attack",
+			baseStat: 80,
+			expected: 135,
+		},
+	}
+
+	for _, cs := range cases {
+		actual := garchomp.actualStat(cs.stat, cs.baseStat)
+		if actual != cs.expected {
+			t.Errorf("%s does not match: %v vs %v", cs.stat, actual, cs.expected)
+		}
+	}
+}
+
+func TestGainEVsCaps(t *testing.T) {
+	owned := &ownedPokemon{
+		evs: map[string]int{"attack": 251, "speed": 252, "defense": 6},
+	}
+	defeated := pokeapi.SpecificPokemonResp{}
+	err := json.Unmarshal([]byte(`{"stats": [
+		{"effort": 3, "stat": {"name": "attack"}},
+		{"effort": 3, "stat": {"name": "hp"}}
+	]}`), &defeated)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	owned.gainEVs(defeated)
+	if owned.evs["attack"] != 252 {
+		t.Errorf("attack EVs should stop at the per-stat cap: %v", owned.evs["attack"])
+	}
+	if owned.evs["hp"] != 0 {
+		t.Errorf("hp EVs should stop at the total cap: %v", owned.evs["hp"])
+	}
+}
+
+func TestKnownMoves(t *testing.T) {
+	info := pokeapi.SpecificPokemonResp{}
+	err := json.Unmarshal([]byte(`{"moves": [
+		{"move": {"name": "tackle"}, "version_group_details": [{"level_learned_at": 1, "move_learn_method": {"name": "level-up"}}]},
+		{"move": {"name": "growl"}, "version_group_details": [{"level_learned_at": 3, "move_learn_method": {"name": "level-up"}}]},
+		{"move": {"name": "vine-whip"}, "version_group_details": [{"level_learned_at": 9, "move_learn_method": {"name": "level-up"}}]},
+		{"move": {"name": "leech-seed"}, "version_group_details": [{"level_learned_at": 7, "move_learn_method": {"name": "level-up"}}]},
+		{"move": {"name": "razor-leaf"}, "version_group_details": [
+			{"level_learned_at": 27, "move_learn_method": {"name": "level-up"}},
+			{"level_learned_at": 12, "move_learn_method": {"name": "level-up"}}
+		]},
+		{"move": {"name": "solar-beam"}, "version_group_details": [{"level_learned_at": 0, "move_learn_method": {"name": "machine"}}]}
+	]}`), &info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"growl", "leech-seed", "vine-whip", "razor-leaf"}
+	actual := knownMoves(info, 15)
+	if len(actual) != len(expected) {
+		t.Errorf("The lengths are not equal: %v vs %v", actual, expected)
+		return
+	}
+	for i := range actual {
+		if actual[i] != expected[i] {
+			t.Errorf("The moves do not match: %v vs %v", actual[i], expected[i])
+		}
+	}
+}
+
+func TestRandomNatureFallback(t *testing.T) {
+	// every request fails
+	cfg := newConfig(pokeapi.NewClientWithTransport(time.Minute, stubTransport{}), defaultShinyRate, false, "en")
+	defer cfg.pokeapiClient.Close()
+
+	nature := randomNature(&cfg)
+	if nature.Name != defaultNature || nature.IncreasedStat != nil || nature.DecreasedStat != nil {
+		t.Errorf("expected the neutral default nature: %+v", nature)
+	}
+}
@@ -21,31 +21,13 @@ func startRepl(cfg *config) {
 
 		command, exists := commandMap[input[0]]
 		if exists {
-			if input[0] == "explore" {
-				if len(input) == 1 {
-					cfg.specificLocation = nil
-				} else if len(input) == 2 {
-					cfg.specificLocation = &input[1]
-				} else if len(input) > 2 {
-					fmt.Println("Only enter one location id or name after the explore command")
-					continue
+			args := input[1:]
+			if !command.keepCase {
+				for i := range args {
+					args[i] = strings.ToLower(args[i])
 				}
 			}
-			if input[0] == "catch" || input[0] == "pokedex" {
-				if len(input) == 1 {
-					cfg.specificPokemon = nil
-				} else if len(input) == 2 {
-					cfg.specificPokemon = &input[1]
-				} else if len(input) > 2 {
-					if input[0] == "catch" {
-						fmt.Println("Please enter only one Pokemon id or name after the catch command")
-					} else {
-						fmt.Println("Please enter only one Pokemon name after the pokedex command")
-					}
-					continue
-				}
-			}
-			err := command.callback(cfg)
+			err := command.callback(cfg, args...)
 			if err != nil {
 				fmt.Println(err)
 			}
@@ -57,8 +39,12 @@ func startRepl(cfg *config) {
 	}
 }
 
+// cleanInput splits the input into words and lowercases the command word. The
+// arguments are kept as typed, since some, like URLs, are case sensitive.
 func cleanInput(text string) []string {
-	output := strings.ToLower(text)
-	words := strings.Fields(output)
+	words := strings.Fields(text)
+	if len(words) > 0 {
+		words[0] = strings.ToLower(words[0])
+	}
 	return words
 }
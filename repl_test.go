@@ -13,7 +13,7 @@ func TestCleanInput(t *testing.T) {
 		},
 		{
 			input:    "HeLlo WoRlD",
-			expected: []string{"hello", "world"},
+			expected: []string{"hello", "WoRlD"},
 		},
 		{
 			input:    "    hello     world     ",
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/aspiringVegetarian/PokedexCLI/internal/pokeapi"
+)
+
+// searchEntry is everything the search command can filter on for one Pokemon,
+// combined from its /pokemon and /pokemon-species data.
+type searchEntry struct {
+	name      string
+	id        int
+	types     []string
+	abilities []string
+	color     string
+	legendary bool
+	mythical  bool
+	numbers   map[string]int
+}
+
+// searchTerm is one condition of a search query, such as type:water or bst>500.
+type searchTerm struct {
+	key    string
+	op     string
+	value  string
+	negate bool
+}
+
+// searchOperators are checked in order, so two character operators come first.
+var searchOperators = []string{">=", "<=", ":", ">", "<", "="}
+
+var (
+	searchNumberKeys = []string{"id", "gen", "bst", "height", "weight", "hp", "attack", "defense", "special-attack", "special-defense", "speed"}
+	searchTextKeys   = []string{"name", "type", "ability", "color", "legendary", "mythical"}
+)
+
+func newSearchEntry(info pokeapi.SpecificPokemonResp, species pokeapi.SpecificPokemonSpeciesResp) searchEntry {
+
+	entry := searchEntry{
+		name:      info.Name,
+		id:        dexNumber(info),
+		types:     pokemonTypeNames(info),
+		color:     species.Color.Name,
+		legendary: species.IsLegendary,
+		mythical:  species.IsMythical,
+		numbers: map[string]int{
+			"bst":    baseStatTotal(info),
+			"height": info.Height,
+			"weight": info.Weight,
+		},
+	}
+	entry.numbers["id"] = entry.id
+	if generation, err := pokeapi.ResourceID(species.Generation.URL); err == nil {
+		entry.numbers["gen"] = generation
+	}
+	for _, content := range info.Abilities {
+		entry.abilities = append(entry.abilities, content.Ability.Name)
+	}
+	for _, content := range info.Stats {
+		entry.numbers[content.Stat.Name] = content.BaseStat
+	}
+	return entry
+}
+
+// parseQuery turns search arguments into terms. Each argument is key, operator
+// and value with no spaces, optionally prefixed by - to negate it. A bare word
+// matches part of the Pokemon's name.
+func parseQuery(args []string) ([]searchTerm, error) {
+
+	terms := []searchTerm{}
+	for _, arg := range args {
+		term := searchTerm{}
+		if strings.HasPrefix(arg, "-") {
+			term.negate = true
+			arg = arg[1:]
+		}
+
+		for _, op := range searchOperators {
+			if key, value, found := strings.Cut(arg, op); found {
+				term.key, term.op, term.value = key, op, value
+				break
+			}
+		}
+		if term.op == "" {
+			term.key, term.op, term.value = "name", ":", arg
+		}
+		if term.value == "" {
+			return nil, fmt.Errorf("missing value in %s", arg)
+		}
+
+		switch {
+		case slices.Contains(searchNumberKeys, term.key):
+			if _, err := strconv.Atoi(term.value); err != nil {
+				return nil, fmt.Errorf("%s needs a number, not %s", term.key, term.value)
+			}
+		case slices.Contains(searchTextKeys, term.key):
+			if term.op != ":" {
+				return nil, fmt.Errorf("%s can only be matched with :, as in %s:%s", term.key, term.key, term.value)
+			}
+		default:
+			return nil, fmt.Errorf("unknown search key %s", term.key)
+		}
+		terms = append(terms, term)
+	}
+	return terms, nil
+}
+
+// matches reports whether the entry satisfies every term.
+func (entry searchEntry) matches(terms []searchTerm) bool {
+
+	for _, term := range terms {
+		if entry.matchesTerm(term) == term.negate {
+			return false
+		}
+	}
+	return true
+}
+
+func (entry searchEntry) matchesTerm(term searchTerm) bool {
+
+	switch term.key {
+	case "name":
+		return strings.Contains(entry.name, term.value)
+	case "type":
+		return slices.Contains(entry.types, term.value)
+	case "ability":
+		return slices.Contains(entry.abilities, term.value)
+	case "color":
+		return entry.color == term.value
+	case "legendary":
+		return strconv.FormatBool(entry.legendary) == term.value
+	case "mythical":
+		return strconv.FormatBool(entry.mythical) == term.value
+	}
+
+	actual, ok := entry.numbers[term.key]
+	if !ok {
+		return false
+	}
+	want, _ := strconv.Atoi(term.value)
+	switch term.op {
+	case ">":
+		return actual > want
+	case "<":
+		return actual < want
+	case ">=":
+		return actual >= want
+	case "<=":
+		return actual <= want
+	}
+	return actual == want
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aspiringVegetarian/PokedexCLI/internal/pokeapi"
+)
+
+func TestParseQueryErrors(t *testing.T) {
+	cases := [][]string{
+		{"bst>high"},
+		{"type>water"},
+		{"nickname:sparky"},
+		{"type:"},
+	}
+
+	for _, cs := range cases {
+		if _, err := parseQuery(cs); err == nil {
+			t.Errorf("expected an error for %v", cs)
+		}
+	}
+}
+
+func TestSearchMatches(t *testing.T) {
+	kingdra := searchEntry{
+		name:      "kingdra",
+		id:        230,
+		types:     []string{"water", "dragon"},
+		abilities: []string{"swift-swim", "sniper", "damp"},
+		color:     "blue",
+		numbers:   map[string]int{"id": 230, "gen": 2, "bst": 540, "speed": 85},
+	}
+
+	cases := []struct {
+		query    []string
+		expected bool
+	}{
+		{
+			query:    []string{"type:water", "bst>500", "ability:swift-swim"},
+			expected: true,
+		},
+		{
+			query:    []string{"type:water", "gen:3"},
+			expected: false,
+		},
+		{
+			query:    []string{"king", "speed>=85", "-type:fire"},
+			expected: true,
+		},
+		{
+			query:    []string{"bst<=539"},
+			expected: false,
+		},
+		{
+			query:    []string{"legendary:false", "color:blue"},
+			expected: true,
+		},
+	}
+
+	for _, cs := range cases {
+		terms, err := parseQuery(cs.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual := kingdra.matches(terms); actual != cs.expected {
+			t.Errorf("The match does not agree for %v: %v vs %v", cs.query, actual, cs.expected)
+		}
+	}
+}
+
+// stubTransport answers PokeAPI requests with canned bodies, keyed by the part
+// of the path after /api/v2/.
+type stubTransport map[string]string
+
+func (responses stubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, exists := responses[strings.TrimPrefix(req.URL.Path, "/api/v2/")]
+	if !exists {
+		return &http.Response{StatusCode: http.StatusNotFound, Body: http.NoBody, Request: req}, nil
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body)), Request: req}, nil
+}
+
+func TestCommandSearch(t *testing.T) {
+	client := pokeapi.NewClientWithTransport(time.Minute, stubTransport{
+		"pokemon/bulbasaur": `{"name": "bulbasaur", "id": 1, "species": {"name": "bulbasaur"},
+			"types": [{"type": {"name": "grass"}}, {"type": {"name": "poison"}}]}`,
+		"pokemon-species/bulbasaur": `{"name": "bulbasaur", "color": {"name": "green"},
+			"generation": {"url": "https://pokeapi.co/api/v2/generation/1/"}}`,
+	})
+	cfg := newConfig(client, defaultShinyRate, false, "en")
+	defer cfg.pokeapiClient.Close()
+	cfg.pokedexSeen["bulbasaur"] = pokeapi.SpecificPokemonResp{Name: "bulbasaur"}
+
+	if err := commandSearch(&cfg, "type:grass", "gen:1"); err != nil {
+		t.Fatal(err)
+	}
+	entry, indexed := cfg.searchIndex["bulbasaur"]
+	if !indexed || entry.color != "green" || entry.numbers["gen"] != 1 {
+		t.Errorf("expected the Pokedex entry to be indexed: %+v", entry)
+	}
+}
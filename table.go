@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	highlightColor = "\x1b[1;32m"
+	resetColor     = "\x1b[0m"
+)
+
+// tableRow is a labelled row of cells. Cells whose highlight flag is set are
+// printed in bold green to mark the best value in the row.
+type tableRow struct {
+	label     string
+	cells     []string
+	highlight []bool
+}
+
+// printTable prints rows under header, padding every column to its widest cell.
+// Padding is computed before highlighting so escape codes do not skew it.
+func printTable(header []string, rows []tableRow) {
+
+	widths := make([]int, len(header)+1)
+	for i, title := range header {
+		widths[i+1] = len(title)
+	}
+	for _, row := range rows {
+		widths[0] = max(widths[0], len(row.label))
+		for i, cell := range row.cells {
+			widths[i+1] = max(widths[i+1], len(cell))
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%-*s", widths[0], ""))
+	for i, title := range header {
+		sb.WriteString(fmt.Sprintf("  %-*s", widths[i+1], title))
+	}
+	fmt.Println(strings.TrimRight(sb.String(), " "))
+
+	for _, row := range rows {
+		sb.Reset()
+		sb.WriteString(fmt.Sprintf("%-*s", widths[0], row.label))
+		for i, cell := range row.cells {
+			padded := fmt.Sprintf("%-*s", widths[i+1], cell)
+			if i < len(row.highlight) && row.highlight[i] {
+				padded = highlightColor + padded + resetColor
+			}
+			sb.WriteString("  " + padded)
+		}
+		fmt.Println(strings.TrimRight(sb.String(), " "))
+	}
+}
+
+// highlightMax flags the largest values, unless every value is the same.
+// Entries marked as skipped are never flagged.
+func highlightMax(values []float64, skip []bool) []bool {
+
+	best := 0.0
+	found := false
+	allEqual := true
+	for i, value := range values {
+		if skip != nil && skip[i] {
+			continue
+		}
+		if found && value != best {
+			allEqual = false
+		}
+		if !found || value > best {
+			best = value
+			found = true
+		}
+	}
+
+	highlight := make([]bool, len(values))
+	if allEqual {
+		return highlight
+	}
+	for i, value := range values {
+		highlight[i] = value == best && (skip == nil || !skip[i])
+	}
+	return highlight
+}
@@ -0,0 +1,38 @@
+package main
+
+import "testing"
+
+func TestHighlightMax(t *testing.T) {
+	cases := []struct {
+		values   []float64
+		skip     []bool
+		expected []bool
+	}{
+		{
+			values:   []float64{45, 60, 60},
+			expected: []bool{false, true, true},
+		},
+		{
+			values:   []float64{80, 80},
+			expected: []bool{false, false},
+		},
+		{
+			values:   []float64{4, 2, 0.5},
+			skip:     []bool{true, false, false},
+			expected: []bool{false, true, false},
+		},
+	}
+
+	for _, cs := range cases {
+		actual := highlightMax(cs.values, cs.skip)
+		for i := range actual {
+			if actual[i] != cs.expected[i] {
+				t.Errorf("The highlights do not match for %v: %v vs %v",
+					cs.values,
+					actual,
+					cs.expected)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/aspiringVegetarian/PokedexCLI/internal/pokeapi"
+)
+
+// damageMultiplier returns how effective an attack of the given type is
+// against a Pokemon with defenderTypes. Dual types multiply together.
+func damageMultiplier(attack pokeapi.SpecificTypeResp, defenderTypes []string) float64 {
+
+	multiplier := 1.0
+	for _, defender := range defenderTypes {
+		for _, relation := range attack.DamageRelations.NoDamageTo {
+			if relation.Name == defender {
+				multiplier *= 0
+			}
+		}
+		for _, relation := range attack.DamageRelations.HalfDamageTo {
+			if relation.Name == defender {
+				multiplier *= 0.5
+			}
+		}
+		for _, relation := range attack.DamageRelations.DoubleDamageTo {
+			if relation.Name == defender {
+				multiplier *= 2
+			}
+		}
+	}
+	return multiplier
+}
+
+// bestDamageMultiplier returns the most effective multiplier any of the
+// attacker's types has against defenderTypes.
+func bestDamageMultiplier(cfg *config, attackerTypes, defenderTypes []string) (float64, error) {
+
+	best := 0.0
+	for _, attackerType := range attackerTypes {
+		attack, err := cfg.pokeapiClient.GetType(attackerType)
+		if err != nil {
+			return 0, err
+		}
+		best = max(best, damageMultiplier(attack, defenderTypes))
+	}
+	return best, nil
+}
+
+func pokemonTypeNames(info pokeapi.SpecificPokemonResp) []string {
+
+	names := make([]string, 0, len(info.Types))
+	for _, content := range info.Types {
+		names = append(names, content.Type.Name)
+	}
+	return names
+}
+
+func formatMultiplier(multiplier float64) string {
+	return strconv.FormatFloat(multiplier, 'f', -1, 64) + "x"
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aspiringVegetarian/PokedexCLI/internal/pokeapi"
+)
+
+func TestDamageMultiplier(t *testing.T) {
+	ground := pokeapi.SpecificTypeResp{}
+	err := json.Unmarshal([]byte(`{"name": "ground", "damage_relations": {
+		"no_damage_to": [{"name": "flying"}],
+		"half_damage_to": [{"name": "bug"}, {"name": "grass"}],
+		"double_damage_to": [{"name": "poison"}, {"name": "rock"}, {"name": "steel"}, {"name": "fire"}, {"name": "electric"}]
+	}}`), &ground)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		input    []string
+		expected float64
+	}{
+		{
+			input:    []string{"normal"},
+			expected: 1,
+		},
+		{
+			input:    []string{"fire"},
+			expected: 2,
+		},
+		{
+			input:    []string{"rock", "steel"},
+			expected: 4,
+		},
+		{
+			input:    []string{"grass", "poison"},
+			expected: 1,
+		},
+		{
+			input:    []string{"bug", "grass"},
+			expected: 0.25,
+		},
+		{
+			input:    []string{"electric", "flying"},
+			expected: 0,
+		},
+	}
+
+	for _, cs := range cases {
+		actual := damageMultiplier(ground, cs.input)
+		if actual != cs.expected {
+			t.Errorf("The multipliers do not match for %v: %v vs %v",
+				cs.input,
+				actual,
+				cs.expected)
+		}
+	}
+}
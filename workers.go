@@ -0,0 +1,75 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const (
+	progressBarWidth = 30
+	// maxReportedErrors keeps a failing run, such as prefetching while offline,
+	// from printing an error for every single call.
+	maxReportedErrors = 5
+)
+
+// runWorkers calls work for every index from 0 to count-1, running at most
+// concurrency calls at once. When label is set a progress bar is drawn while
+// the work runs. Every call runs even if some fail; the first few errors are
+// joined along with a count of the rest.
+func runWorkers(count, concurrency int, label string, work func(i int) error) error {
+
+	errs := make([]error, count)
+	workers := make(chan struct{}, max(concurrency, 1))
+	var wg sync.WaitGroup
+	var mux sync.Mutex
+	done := 0
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		workers <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-workers }()
+			errs[i] = work(i)
+			if label == "" {
+				return
+			}
+			mux.Lock()
+			defer mux.Unlock()
+			done++
+			printProgress(label, done, count)
+		}()
+	}
+	wg.Wait()
+	if label != "" && count > 0 {
+		fmt.Println()
+	}
+
+	return joinErrors(errs)
+}
+
+// joinErrors joins the non-nil errors, keeping at most maxReportedErrors.
+func joinErrors(errs []error) error {
+
+	failed := []error{}
+	for _, err := range errs {
+		if err != nil {
+			failed = append(failed, err)
+		}
+	}
+	if len(failed) <= maxReportedErrors {
+		return errors.Join(failed...)
+	}
+	more := fmt.Errorf("and %v more", len(failed)-maxReportedErrors)
+	return errors.Join(append(failed[:maxReportedErrors], more)...)
+}
+
+func printProgress(label string, done, total int) {
+
+	filled := progressBarWidth
+	if total > 0 {
+		filled = done * progressBarWidth / total
+	}
+	fmt.Printf("\r%s [%s%s] %v/%v", label, strings.Repeat("#", filled), strings.Repeat("-", progressBarWidth-filled), done, total)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRunWorkers(t *testing.T) {
+	const count = 50
+	const concurrency = 4
+
+	var running, peak atomic.Int32
+	results := make([]int, count)
+	err := runWorkers(count, concurrency, "", func(i int) error {
+		now := running.Add(1)
+		defer running.Add(-1)
+		for {
+			old := peak.Load()
+			if now <= old || peak.CompareAndSwap(old, now) {
+				break
+			}
+		}
+		results[i] = i * 2
+		if i == 7 {
+			return fmt.Errorf("failed %v", i)
+		}
+		return nil
+	})
+
+	if err == nil {
+		t.Errorf("expected the failed call's error")
+	}
+	if peak.Load() > concurrency {
+		t.Errorf("expected at most %v calls at once, got %v", concurrency, peak.Load())
+	}
+	for i, result := range results {
+		if result != i*2 {
+			t.Errorf("expected every call to run: index %v has %v", i, result)
+		}
+	}
+}
+
+func TestRunWorkersCapsErrors(t *testing.T) {
+	err := runWorkers(20, 4, "", func(i int) error {
+		return fmt.Errorf("failed %v", i)
+	})
+
+	lines := strings.Split(err.Error(), "\n")
+	if len(lines) != maxReportedErrors+1 || lines[0] != "failed 0" {
+		t.Errorf("expected the first %v errors: %q", maxReportedErrors, lines)
+	}
+	if last := lines[len(lines)-1]; last != "and 15 more" {
+		t.Errorf("expected a count of the other errors: %q", last)
+	}
+}